@@ -1,16 +1,56 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/config"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/propertytax"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/web"
 	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/web/handlers"
+	"io"
 	"log"
 	"net/http"
+	"os"
+	"time"
 )
 
-const port = 3000
-
 func main() {
-	fmt.Printf("Starting server at port %d\n", port)
-	handler := http.HandlerFunc(handlers.PaymentScheduleHandler)
-	log.Fatal(http.ListenAndServe(":3000", handler))
+	cfg, printConfig, err := config.Load(os.Args[1:], os.Getenv)
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+
+	if printConfig {
+		out, _ := json.MarshalIndent(cfg, "", "    ")
+		fmt.Println(string(out))
+		return
+	}
+
+	if cfg.Rules.Municipalities != "" {
+		if err := propertytax.LoadMunicipalities(cfg.Rules.Municipalities); err != nil {
+			log.Fatalf("unable to load the municipalities: %v", err)
+		}
+	}
+
+	if !cfg.Logs(config.Warn) {
+		handlers.WarnLog = log.New(io.Discard, "", 0)
+	}
+
+	handler := handlers.API(cfg.WebCORS())
+	if cfg.Logs(config.Debug) {
+		handler = web.LogRequests(handler)
+	}
+
+	server := &http.Server{
+		Addr:         fmt.Sprintf(":%d", cfg.Port),
+		Handler:      handler,
+		ReadTimeout:  time.Duration(cfg.ReadTimeout),
+		WriteTimeout: time.Duration(cfg.WriteTimeout),
+		IdleTimeout:  time.Duration(cfg.IdleTimeout),
+	}
+
+	if cfg.Logs(config.Info) {
+		fmt.Printf("Starting server at port %d\n", cfg.Port)
+	}
+	log.Fatal(server.ListenAndServe())
 }
@@ -0,0 +1,282 @@
+// Package config loads the settings of the server from its defaults, an optional JSON file, environment variables and
+// command line flags, each one overriding the previous.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/web"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// envPrefix is the prefix of the environment variables read by the server.
+const envPrefix = "MORTGAGE_"
+
+// Log levels, from the most to the least verbose
+const (
+	Debug = "debug"
+	Info  = "info"
+	Warn  = "warn"
+	Error = "error"
+)
+
+// levels are the log levels by verbosity.
+var levels = map[string]int{Debug: 0, Info: 1, Warn: 2, Error: 3}
+
+// Config holds the settings of the server.
+type Config struct {
+	Port         int      `json:"port" validate:"gte=1,lte=65535"`
+	ReadTimeout  Duration `json:"readTimeout" validate:"gte=0"`
+	WriteTimeout Duration `json:"writeTimeout" validate:"gte=0"`
+	IdleTimeout  Duration `json:"idleTimeout" validate:"gte=0"`
+	CORS         CORS     `json:"cors"`
+	Rules        Rules    `json:"rules"`
+	LogLevel     string   `json:"logLevel" validate:"oneof=debug info warn error"`
+}
+
+// CORS holds the cross-origin resource sharing policy of the API.
+type CORS struct {
+	AllowedOrigins   []string `json:"allowedOrigins" validate:"dive,required"`
+	AllowedMethods   []string `json:"allowedMethods" validate:"dive,required"`
+	AllowedHeaders   []string `json:"allowedHeaders" validate:"dive,required"`
+	AllowCredentials bool     `json:"allowCredentials"`
+	MaxAge           Duration `json:"maxAge" validate:"gte=0"`
+}
+
+// Rules holds the paths of the files replacing the embedded rule tables, the embedded ones are used when empty.
+type Rules struct {
+	Municipalities string `json:"municipalities" validate:"omitempty,file"`
+}
+
+// Default returns the settings used when no other is provided.
+func Default() Config {
+	return Config{
+		Port:         3000,
+		ReadTimeout:  Duration(5 * time.Second),
+		WriteTimeout: Duration(10 * time.Second),
+		IdleTimeout:  Duration(2 * time.Minute),
+		CORS: CORS{
+			AllowedOrigins: []string{"http://localhost:3001"},
+			AllowedMethods: []string{http.MethodPost},
+			AllowedHeaders: []string{"Content-Type"},
+		},
+		LogLevel: Info,
+	}
+}
+
+// Load returns the settings of the server from the command line arguments, without the program name, and the
+// environment. The JSON file is read from the path in the -config flag or the MORTGAGE_CONFIG variable. It also
+// returns whether the settings were asked to be printed.
+func Load(args []string, getenv func(string) string) (Config, bool, error) {
+	fs := flag.NewFlagSet("mortgage-calculator", flag.ContinueOnError)
+	configPath := fs.String("config", getenv(envPrefix+"CONFIG"), "path of the JSON configuration file")
+	printConfig := fs.Bool("print-config", false, "print the configuration and exit")
+	values := make([]*value, len(settings))
+	for i, s := range settings {
+		values[i] = &value{boolean: s.boolean}
+		fs.Var(values[i], s.flag, fmt.Sprintf("%s (env %s)", s.usage, s.env()))
+	}
+	if err := fs.Parse(args); err != nil {
+		return Config{}, false, err
+	}
+
+	cfg := Default()
+	if *configPath != "" {
+		if err := cfg.readFile(*configPath); err != nil {
+			return Config{}, false, err
+		}
+	}
+
+	for i, s := range settings {
+		v := getenv(s.env())
+		if values[i].set {
+			v = values[i].text
+		} else if v == "" {
+			continue
+		}
+		if err := s.apply(&cfg, v); err != nil {
+			return Config{}, false, fmt.Errorf("%s: %w", s.flag, err)
+		}
+	}
+
+	return cfg, *printConfig, cfg.Validate()
+}
+
+// Validate returns the field errors of the settings, if any.
+func (c Config) Validate() error {
+	err := validate.Check(c)
+	if err != nil {
+		return err
+	}
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" && c.CORS.AllowCredentials {
+			msg := "the * origin can not be allowed with credentials"
+			return validate.FieldErrors{{Field: "allowedOrigins", Error: msg}}
+		}
+		if _, err := path.Match(origin, ""); err != nil {
+			msg := fmt.Sprintf("%q is not a valid origin pattern", origin)
+			return validate.FieldErrors{{Field: "allowedOrigins", Error: msg}}
+		}
+	}
+	return nil
+}
+
+// Logs returns whether messages of the level are logged.
+func (c Config) Logs(level string) bool {
+	return levels[level] >= levels[c.LogLevel]
+}
+
+// WebCORS returns the CORS policy of the API.
+func (c Config) WebCORS() web.CORS {
+	return web.CORS{
+		AllowedOrigins:   c.CORS.AllowedOrigins,
+		AllowedMethods:   c.CORS.AllowedMethods,
+		AllowedHeaders:   c.CORS.AllowedHeaders,
+		AllowCredentials: c.CORS.AllowCredentials,
+		MaxAge:           time.Duration(c.CORS.MaxAge),
+	}
+}
+
+// readFile overrides the settings with the ones in the JSON file at the path.
+func (c *Config) readFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(c); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return nil
+}
+
+// setting is a setting that can be provided as a flag or an environment variable.
+type setting struct {
+	flag    string
+	usage   string
+	boolean bool
+	apply   func(c *Config, v string) error
+}
+
+// env returns the name of the environment variable of the setting.
+func (s setting) env() string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(s.flag, "-", "_"))
+}
+
+// settings are the settings that can be provided as flags or environment variables, lists are comma separated.
+var settings = []setting{
+	{flag: "port", usage: "port the server listens on", apply: func(c *Config, v string) (err error) {
+		c.Port, err = strconv.Atoi(v)
+		return err
+	}},
+	{flag: "read-timeout", usage: "maximum duration of reading a request", apply: func(c *Config, v string) error {
+		return c.ReadTimeout.parse(v)
+	}},
+	{flag: "write-timeout", usage: "maximum duration of writing a response", apply: func(c *Config, v string) error {
+		return c.WriteTimeout.parse(v)
+	}},
+	{flag: "idle-timeout", usage: "maximum duration of idle connections", apply: func(c *Config, v string) error {
+		return c.IdleTimeout.parse(v)
+	}},
+	{flag: "cors-allowed-origins", usage: "origins or origin patterns allowed", apply: func(c *Config, v string) error {
+		c.CORS.AllowedOrigins = list(v)
+		return nil
+	}},
+	{flag: "cors-allowed-methods", usage: "methods allowed cross-origin", apply: func(c *Config, v string) error {
+		c.CORS.AllowedMethods = list(v)
+		return nil
+	}},
+	{flag: "cors-allowed-headers", usage: "headers allowed cross-origin", apply: func(c *Config, v string) error {
+		c.CORS.AllowedHeaders = list(v)
+		return nil
+	}},
+	{flag: "cors-allow-credentials", usage: "allow credentials cross-origin", boolean: true,
+		apply: func(c *Config, v string) (err error) {
+			c.CORS.AllowCredentials, err = strconv.ParseBool(v)
+			return err
+		}},
+	{flag: "cors-max-age", usage: "duration preflights may be cached", apply: func(c *Config, v string) error {
+		return c.CORS.MaxAge.parse(v)
+	}},
+	{flag: "municipalities", usage: "path of the mill rates table", apply: func(c *Config, v string) error {
+		c.Rules.Municipalities = v
+		return nil
+	}},
+	{flag: "log-level", usage: "debug, info, warn or error", apply: func(c *Config, v string) error {
+		c.LogLevel = strings.ToLower(v)
+		return nil
+	}},
+}
+
+// list returns the comma separated values without surrounding spaces.
+func list(v string) []string {
+	var values []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
+
+// value records the text of a flag, it implements flag.Value.
+type value struct {
+	text    string
+	set     bool
+	boolean bool
+}
+
+// String returns the text of the flag.
+func (v *value) String() string {
+	return v.text
+}
+
+// Set records the text of the flag.
+func (v *value) Set(text string) error {
+	v.text, v.set = text, true
+	return nil
+}
+
+// IsBoolFlag returns whether the flag can be provided without a value.
+func (v *value) IsBoolFlag() bool {
+	return v.boolean
+}
+
+// Duration is a time.Duration written as a string such as "5s" in the JSON file.
+type Duration time.Duration
+
+// MarshalJSON returns the duration as a JSON string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON reads the duration from a JSON string.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return errors.New("duration must be a string such as \"5s\"")
+	}
+	return d.parse(s)
+}
+
+// parse reads the duration from a string such as "5s".
+func (d *Duration) parse(s string) error {
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
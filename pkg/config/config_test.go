@@ -0,0 +1,111 @@
+package config
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(name string) string { return vars[name] }
+	}
+
+	writeFile := func(t *testing.T, content string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "config.json")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	t.Run("should return the defaults when nothing is provided", func(t *testing.T) {
+		got, printConfig, err := Load(nil, env(nil))
+		tests.AssertNilError(t, err)
+		tests.AssertSameInt(t, got.Port, 3000)
+		if got.LogLevel != Info || printConfig {
+			t.Errorf("got %s and %v, want %s and false", got.LogLevel, printConfig, Info)
+		}
+	})
+
+	t.Run("should override the file with the environment and the environment with the flags", func(t *testing.T) {
+		path := writeFile(t, `{"port": 4000, "readTimeout": "1s", "writeTimeout": "2s", "logLevel": "warn",
+			"cors": {"allowedOrigins": ["https://*.example.com"], "maxAge": "10m"}}`)
+		vars := map[string]string{"MORTGAGE_CONFIG": path, "MORTGAGE_PORT": "5000", "MORTGAGE_READ_TIMEOUT": "3s"}
+		got, printConfig, err := Load([]string{"--port", "6000", "--cors-allow-credentials", "--print-config"}, env(vars))
+		tests.AssertNilError(t, err)
+		tests.AssertSameInt(t, got.Port, 6000)
+		tests.AssertSameInt(t, int(got.ReadTimeout), int(3*time.Second))
+		tests.AssertSameInt(t, int(got.WriteTimeout), int(2*time.Second))
+		tests.AssertSameInt(t, int(got.CORS.MaxAge), int(10*time.Minute))
+		if got.LogLevel != Warn || got.CORS.AllowedOrigins[0] != "https://*.example.com" {
+			t.Errorf("got %s and %v, want the ones of the file", got.LogLevel, got.CORS.AllowedOrigins)
+		}
+		if !got.CORS.AllowCredentials || !printConfig {
+			t.Errorf("got %v and %v, want true and true", got.CORS.AllowCredentials, printConfig)
+		}
+	})
+
+	t.Run("should split the lists on commas", func(t *testing.T) {
+		vars := map[string]string{"MORTGAGE_CORS_ALLOWED_ORIGINS": "https://a.example.com, https://b.example.com,"}
+		got, _, err := Load(nil, env(vars))
+		tests.AssertNilError(t, err)
+		tests.AssertSameInt(t, len(got.CORS.AllowedOrigins), 2)
+		if got.CORS.AllowedOrigins[1] != "https://b.example.com" {
+			t.Errorf("got %s, want https://b.example.com", got.CORS.AllowedOrigins[1])
+		}
+	})
+
+	t.Run("should return an error when a value can not be parsed", func(t *testing.T) {
+		for _, args := range [][]string{{"--port", "http"}, {"--idle-timeout", "5"}, {"--unknown"}} {
+			if _, _, err := Load(args, env(nil)); err == nil {
+				t.Errorf("got nil, want an error for %v", args)
+			}
+		}
+	})
+
+	t.Run("should return an error when the file has unknown settings", func(t *testing.T) {
+		path := writeFile(t, `{"prot": 4000}`)
+		if _, _, err := Load([]string{"--config", path}, env(nil)); err == nil {
+			t.Errorf("got nil, want an error")
+		}
+	})
+
+	t.Run("should return the field errors of invalid settings", func(t *testing.T) {
+		cases := map[string][]string{
+			"port":           {"--port", "70000"},
+			"logLevel":       {"--log-level", "loud"},
+			"municipalities": {"--municipalities", "missing.json"},
+			"allowedOrigins": {"--cors-allowed-origins", "https://[example.com"},
+		}
+		for field, args := range cases {
+			_, _, err := Load(args, env(nil))
+			fields := validate.GetFieldErrors(err)
+			if len(fields) == 0 || fields[0].Field != field {
+				t.Errorf("got %v, want an error on %s", err, field)
+			}
+		}
+	})
+
+	t.Run("should return a field error when any origin is allowed with credentials", func(t *testing.T) {
+		_, _, err := Load([]string{"--cors-allowed-origins", "*", "--cors-allow-credentials"}, env(nil))
+		fields := validate.GetFieldErrors(err)
+		if len(fields) == 0 || fields[0].Field != "allowedOrigins" {
+			t.Errorf("got %v, want an error on allowedOrigins", err)
+		}
+	})
+}
+
+func TestLogs(t *testing.T) {
+	c := Default()
+	c.LogLevel = Warn
+	for level, want := range map[string]bool{Debug: false, Info: false, Warn: true, Error: true} {
+		if got := c.Logs(level); got != want {
+			t.Errorf("%s: got %v, want %v", level, got, want)
+		}
+	}
+}
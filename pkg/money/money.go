@@ -0,0 +1,193 @@
+// Package money provides a fixed-point type to represent and operate with amounts of money without the rounding
+// errors of floating point arithmetic.
+package money
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode defines how an amount is rounded when it falls between two cents.
+type RoundingMode string
+
+const (
+	// HalfUp rounds half a cent away from zero, the mode used by most lenders.
+	HalfUp RoundingMode = "HALFUP"
+	// HalfEven rounds half a cent to the nearest even cent, also known as banker's rounding.
+	HalfEven RoundingMode = "HALFEVEN"
+)
+
+// ratePrecision is the number of decimals of a percentage rate kept when multiplying an amount by it.
+const ratePrecision = 1_000_000
+
+// MaxAmount is the largest amount supported, ten trillion dollars. Results beyond it, in either sign, are clamped right
+// past it instead of wrapping around, so a handful of them can still be added together and Check reports them.
+const MaxAmount Money = 1_000_000_000_000_000
+
+// outOfRange is the amount results beyond MaxAmount are clamped to.
+const outOfRange = MaxAmount + 1
+
+// errors for money operations
+var (
+	ErrInvalidRoundingMode = errors.New("rounding mode must be halfUp or halfEven")
+	ErrInvalidAmount       = errors.New("amount must be a number with at most two decimals")
+	ErrAmountOutOfRange    = errors.New("amount exceeds the supported ten trillion dollars")
+)
+
+// Money is an amount of money expressed in cents.
+type Money int64
+
+// FromFloat returns the amount of dollars as money, rounded to the nearest cent.
+func FromFloat(dollars float64) Money {
+	return Round(dollars, HalfUp)
+}
+
+// Check returns ErrAmountOutOfRange when any of the amounts is beyond MaxAmount.
+func Check(amounts ...Money) error {
+	for _, m := range amounts {
+		if m > MaxAmount || m < -MaxAmount {
+			return ErrAmountOutOfRange
+		}
+	}
+	return nil
+}
+
+// Round returns the amount of dollars as money, rounded to the cent according to the rounding mode. Amounts beyond
+// MaxAmount, and NaN, are clamped past it.
+func Round(dollars float64, mode RoundingMode) Money {
+	// Drop the floating point noise below a ten thousandth of a cent so halves are detected.
+	cents := math.Round(dollars*100*10_000) / 10_000
+	if math.IsNaN(cents) || math.Abs(cents) > float64(MaxAmount) {
+		return clamp(cents < 0)
+	}
+	if mode == HalfEven {
+		return Money(math.RoundToEven(cents))
+	}
+	return Money(math.Round(cents))
+}
+
+// ParseRoundingMode returns the rounding mode named by s, HalfUp when s is empty.
+func ParseRoundingMode(s string) (RoundingMode, error) {
+	switch RoundingMode(strings.ToUpper(s)) {
+	case "", HalfUp:
+		return HalfUp, nil
+	case HalfEven:
+		return HalfEven, nil
+	}
+	return "", ErrInvalidRoundingMode
+}
+
+// Float64 returns the amount in dollars.
+func (m Money) Float64() float64 {
+	return float64(m) / 100
+}
+
+// MulRate returns the amount multiplied by a percentage rate and divided by the divisor, the product is computed
+// exactly and rounded to the cent according to the rounding mode. Products beyond MaxAmount are clamped past it.
+func (m Money) MulRate(percent float64, divisor int, mode RoundingMode) Money {
+	scaled := math.Round(percent * ratePrecision)
+	if math.IsNaN(scaled) || math.Abs(scaled) > math.MaxInt64/2 {
+		return clamp((m < 0) != (scaled < 0))
+	}
+	rate := int64(scaled)
+	negative := (m < 0) != (rate < 0)
+
+	hi, lo := bits.Mul64(abs(int64(m)), abs(rate))
+	if hi >= uint64(100*ratePrecision*divisor) {
+		return clamp(negative)
+	}
+	quo, rem := bits.Div64(hi, lo, uint64(100*ratePrecision*divisor))
+	if quo > uint64(MaxAmount) {
+		return clamp(negative)
+	}
+
+	return round(quo, rem, uint64(100*ratePrecision*divisor), negative, mode)
+}
+
+// Div returns the amount divided by n, rounded to the cent according to the rounding mode.
+func (m Money) Div(n int64, mode RoundingMode) Money {
+	negative := (m < 0) != (n < 0)
+	return round(abs(int64(m))/abs(n), abs(int64(m))%abs(n), abs(n), negative, mode)
+}
+
+// String returns the amount in dollars with two decimals.
+func (m Money) String() string {
+	sign := ""
+	cents := abs(int64(m))
+	if m < 0 {
+		sign = "-"
+	}
+	return sign + strconv.FormatUint(cents/100, 10) + "." + strconv.FormatUint(cents%100/10, 10) +
+		strconv.FormatUint(cents%10, 10)
+}
+
+// MarshalJSON implements the json.Marshaler interface, the amount is encoded as a number of dollars.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, the amount is decoded from a number of dollars.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	whole, fraction, _ := strings.Cut(s, ".")
+	if len(fraction) > 2 {
+		return ErrInvalidAmount
+	}
+	fraction += strings.Repeat("0", 2-len(fraction))
+
+	dollars, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return ErrInvalidAmount
+	}
+	cents, err := strconv.ParseInt(fraction, 10, 64)
+	if err != nil {
+		return ErrInvalidAmount
+	}
+
+	*m = Money(dollars*100 + cents)
+	if negative {
+		*m = -*m
+	}
+	return nil
+}
+
+// round returns the quotient of a division rounded according to the rounding mode and the remainder.
+func round(quo, rem, divisor uint64, negative bool, mode RoundingMode) Money {
+	switch {
+	case 2*rem > divisor:
+		quo++
+	case 2*rem == divisor && (mode != HalfEven || quo%2 == 1):
+		quo++
+	}
+
+	if negative {
+		return -Money(quo)
+	}
+	return Money(quo)
+}
+
+// clamp returns the amount results beyond MaxAmount are clamped to, with the sign.
+func clamp(negative bool) Money {
+	if negative {
+		return -outOfRange
+	}
+	return outOfRange
+}
+
+// abs returns the absolute value of n.
+func abs(n int64) uint64 {
+	if n < 0 {
+		return uint64(-n)
+	}
+	return uint64(n)
+}
@@ -0,0 +1,133 @@
+package money
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestRound(t *testing.T) {
+	t.Run("should round half a cent away from zero when rounding half up", func(t *testing.T) {
+		AssertSameMoney(t, Round(916.255, HalfUp), 91626)
+		AssertSameMoney(t, Round(916.245, HalfUp), 91625)
+		AssertSameMoney(t, Round(-916.255, HalfUp), -91626)
+	})
+
+	t.Run("should round half a cent to the even cent when rounding half even", func(t *testing.T) {
+		AssertSameMoney(t, Round(916.255, HalfEven), 91626)
+		AssertSameMoney(t, Round(916.245, HalfEven), 91624)
+	})
+
+	t.Run("should round to the nearest cent when the amount is not a half", func(t *testing.T) {
+		AssertSameMoney(t, Round(1832.5149, HalfEven), 183251)
+		AssertSameMoney(t, Round(1832.5151, HalfUp), 183252)
+	})
+}
+
+func TestCheck(t *testing.T) {
+	t.Run("should accept amounts up to the maximum", func(t *testing.T) {
+		if err := Check(0, MaxAmount, -MaxAmount); err != nil {
+			t.Errorf("got %v want nil", err)
+		}
+	})
+
+	t.Run("should clamp and report amounts beyond the maximum instead of wrapping around", func(t *testing.T) {
+		for _, m := range []Money{Round(1e30, HalfUp), Round(-1e30, HalfUp), Round(math.NaN(), HalfUp),
+			MaxAmount.MulRate(1000, 1, HalfUp), Money(math.MaxInt64).MulRate(-200, 1, HalfUp),
+			Money(1).MulRate(1e20, 1, HalfUp)} {
+			if err := Check(m); err != ErrAmountOutOfRange {
+				t.Errorf("%s: got %v want %v", m, err, ErrAmountOutOfRange)
+			}
+		}
+	})
+}
+
+func TestParseRoundingMode(t *testing.T) {
+	t.Run("should default to half up", func(t *testing.T) {
+		got, err := ParseRoundingMode("")
+		AssertRoundingModeAndNilError(t, err, got, HalfUp)
+	})
+
+	t.Run("should ignore the case of the mode", func(t *testing.T) {
+		got, err := ParseRoundingMode("halfEven")
+		AssertRoundingModeAndNilError(t, err, got, HalfEven)
+	})
+
+	t.Run("should return a error if the mode is not supported", func(t *testing.T) {
+		_, err := ParseRoundingMode("ceiling")
+		if err != ErrInvalidRoundingMode {
+			t.Errorf("got %v want %v", err, ErrInvalidRoundingMode)
+		}
+	})
+}
+
+func TestMulRate(t *testing.T) {
+	t.Run("should multiply the amount by the rate exactly", func(t *testing.T) {
+		AssertSameMoney(t, FromFloat(111233).MulRate(4, 1, HalfUp), 444932)
+		AssertSameMoney(t, FromFloat(98800).MulRate(4.29, 12, HalfUp), 35321)
+	})
+
+	t.Run("should round half a cent according to the rounding mode", func(t *testing.T) {
+		AssertSameMoney(t, Money(1).MulRate(50, 1, HalfUp), 1)
+		AssertSameMoney(t, Money(1).MulRate(50, 1, HalfEven), 0)
+		AssertSameMoney(t, Money(3).MulRate(50, 1, HalfEven), 2)
+	})
+
+	t.Run("should keep the sign of the amount", func(t *testing.T) {
+		AssertSameMoney(t, Money(-1000).MulRate(5, 1, HalfUp), -50)
+	})
+}
+
+func TestDiv(t *testing.T) {
+	t.Run("should round half a cent according to the rounding mode", func(t *testing.T) {
+		AssertSameMoney(t, Money(183251).Div(2, HalfUp), 91626)
+		AssertSameMoney(t, Money(183249).Div(2, HalfEven), 91624)
+	})
+}
+
+func TestJSON(t *testing.T) {
+	t.Run("should encode the amount as a number of dollars", func(t *testing.T) {
+		got, _ := json.Marshal([]Money{91626, 5, -120, 0})
+		want := "[916.26,0.05,-1.20,0.00]"
+		if string(got) != want {
+			t.Errorf("got %s want %s", got, want)
+		}
+	})
+
+	t.Run("should decode a number of dollars", func(t *testing.T) {
+		var got []Money
+		err := json.Unmarshal([]byte("[916.26,0.5,-1.2,100]"), &got)
+		if err != nil {
+			t.Fatalf("got %v want nil", err)
+		}
+		want := []Money{91626, 50, -120, 10000}
+		for i := range want {
+			AssertSameMoney(t, got[i], want[i])
+		}
+	})
+
+	t.Run("should return a error if the amount has fractions of a cent", func(t *testing.T) {
+		var got Money
+		err := json.Unmarshal([]byte("916.255"), &got)
+		if err != ErrInvalidAmount {
+			t.Errorf("got %v want %v", err, ErrInvalidAmount)
+		}
+	})
+}
+
+func AssertSameMoney(t testing.TB, got, want Money) {
+	t.Helper()
+	if got != want {
+		t.Errorf("got %s want %s", got, want)
+	}
+}
+
+func AssertRoundingModeAndNilError(t testing.TB, err error, got, want RoundingMode) {
+	t.Helper()
+	if err != nil {
+		t.Errorf("got %v want nil", err)
+	}
+	if got != want {
+		t.Errorf("got %s want %s", got, want)
+	}
+}
@@ -2,6 +2,9 @@ package mortgage
 
 import (
 	"errors"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/propertytax"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/province"
 	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
 	"math"
 	"strings"
@@ -13,6 +16,7 @@ const (
 	Monthly                   = "MONTHLY"
 	minimumAmortizationPeriod = 5
 	maximumAmortizationPeriod = 30
+	defaultTerm               = 5
 )
 
 // errors for calculation operations
@@ -20,6 +24,7 @@ var (
 	ErrPeriodOutOfRange          = errors.New("amortization period out of range")
 	ErrPeriodNotAMultipleOfFive  = errors.New("amortization period must be a 5 years multiple")
 	ErrDownPaymentNotLargeEnough = errors.New("down payment is lower than the minimum 5% of the property price")
+	ErrTermOutOfRange            = errors.New("term must be between 1 year and the amortization period")
 	errInvalidSchedule           = errors.New("amortization schedule not supported")
 )
 
@@ -30,10 +35,15 @@ type Calculator struct {
 	AnnualInterestRate float64 `json:"annualInterestRate" validate:"required"`
 	AmortizationPeriod int     `json:"amortizationPeriod" validate:"required"`
 	Schedule           string  `json:"schedule" validate:"required"`
+	Term               int     `json:"term,omitempty" validate:"gte=0"`
+	Province           string  `json:"province,omitempty"`
+	Municipality       string  `json:"municipality,omitempty"`
+	FirstTimeBuyer     bool    `json:"firstTimeBuyer,omitempty"`
+	Rounding           string  `json:"rounding,omitempty"`
 }
 
 // PaymentSchedule returns the payment value according to the schedule.
-func (c Calculator) PaymentSchedule() (float64, error) {
+func (c Calculator) PaymentSchedule() (money.Money, error) {
 	err := validate.Check(c)
 	if err != nil {
 		return 0, err
@@ -44,38 +54,174 @@ func (c Calculator) PaymentSchedule() (float64, error) {
 		return 0, err
 	}
 
+	_, err = province.Lookup(c.Province)
+	if err != nil {
+		return 0, err
+	}
+
 	principal, err := c.calculateTotalMortgage()
 	if err != nil {
 		return 0, err
 	}
 
-	scheduleRate, err := c.scheduleInterestRate()
+	rounding, err := money.ParseRoundingMode(c.Rounding)
+	if err != nil {
+		return 0, err
+	}
+
+	return schedulePayment(principal, c.AnnualInterestRate, c.AmortizationPeriod, c.Schedule, rounding)
+}
+
+// Summary holds the payment of a mortgage along with the values it is calculated from and its cost over time.
+type Summary struct {
+	PaymentPerSchedule money.Money `json:"paymentPerSchedule"`
+	Principal          money.Money `json:"principal"`
+	CMHCPremium        money.Money `json:"cmhcPremium"`
+	CMHCRate           float64     `json:"cmhcRate"`
+	CMHCPremiumTax     money.Money `json:"cmhcPremiumTax"`
+	NumberOfPayments   int         `json:"numberOfPayments"`
+	Term               int         `json:"term"`
+	TermInterest       money.Money `json:"termInterest"`
+	TotalInterest      money.Money `json:"totalInterest"`
+	TotalPaid          money.Money `json:"totalPaid"`
+	CashToClose        CashToClose `json:"cashToClose"`
+}
+
+// Summary returns the payment along with the principal including the CMHC premium, the premium, the number of payments
+// made until the mortgage is paid off and the interest paid over the term and the amortization.
+func (c Calculator) Summary() (Summary, error) {
+	paymentPerSchedule, err := c.PaymentSchedule()
+	if err != nil {
+		return Summary{}, err
+	}
+
+	schedule, err := c.AmortizationSchedule()
+	if err != nil {
+		return Summary{}, err
+	}
+
+	term, err := c.term()
+	if err != nil {
+		return Summary{}, err
+	}
+
+	frequency, err := paymentFrequency(c.Schedule)
+	if err != nil {
+		return Summary{}, err
+	}
+
+	principal, err := c.calculateTotalMortgage()
+	if err != nil {
+		return Summary{}, err
+	}
+
+	premium, err := c.calculateCMHC()
+	if err != nil {
+		return Summary{}, err
+	}
+
+	CMHCRate, err := c.calculateCMHCRate()
+	if err != nil {
+		return Summary{}, err
+	}
+
+	cashToClose, err := c.CashToClose()
+	if err != nil {
+		return Summary{}, err
+	}
+
+	termPeriods := schedule
+	if n := frequency * term; n < len(schedule) {
+		termPeriods = schedule[:n]
+	}
+
+	interest := totalInterest(schedule)
+	return Summary{
+		PaymentPerSchedule: paymentPerSchedule,
+		Principal:          principal,
+		CMHCPremium:        premium,
+		CMHCRate:           CMHCRate,
+		CMHCPremiumTax:     cashToClose.CMHCPremiumTax,
+		NumberOfPayments:   len(schedule),
+		Term:               term,
+		TermInterest:       totalInterest(termPeriods),
+		TotalInterest:      interest,
+		TotalPaid:          principal + interest,
+		CashToClose:        cashToClose,
+	}, nil
+}
+
+// AmortizationSchedule returns the breakdown of every payment until the mortgage is paid off.
+func (c Calculator) AmortizationSchedule() ([]Period, error) {
+	payment, err := c.PaymentSchedule()
+	if err != nil {
+		return nil, err
+	}
+
+	principal, err := c.calculateTotalMortgage()
+	if err != nil {
+		return nil, err
+	}
+
+	frequency, err := paymentFrequency(c.Schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	rounding, err := money.ParseRoundingMode(c.Rounding)
+	if err != nil {
+		return nil, err
+	}
+
+	return amortize(principal, c.AnnualInterestRate, frequency, payment, frequency*c.AmortizationPeriod, rounding), nil
+}
+
+// schedulePayment returns the payment needed to amortize the principal according to the schedule, rounded to the cent.
+func schedulePayment(principal money.Money, annualInterestRate float64, amortizationPeriod int, schedule string,
+	rounding money.RoundingMode) (money.Money, error) {
+	numberOfPayments, err := totalNumberOfPayments(schedule, amortizationPeriod)
 	if err != nil {
 		return 0, err
 	}
 
-	numberOfPayments, err := c.totalNumberOfPayments()
+	return annuityPayment(principal, annualInterestRate, numberOfPayments, schedule, rounding)
+}
+
+// annuityPayment returns the payment needed to amortize the principal in the number of payments of the schedule,
+// rounded to the cent.
+func annuityPayment(principal money.Money, annualInterestRate float64, numberOfPayments int, schedule string,
+	rounding money.RoundingMode) (money.Money, error) {
+	scheduleRate, err := scheduleInterestRate(annualInterestRate, schedule)
 	if err != nil {
 		return 0, err
 	}
 
-	paymentPerSchedule := principal * scheduleRate * (math.Pow(1+scheduleRate, float64(numberOfPayments))) /
-		(math.Pow(1+scheduleRate, float64(numberOfPayments)) - 1)
+	// Without interest the annuity formula divides zero by zero, the principal is simply split in equal payments.
+	paymentPerSchedule := principal.Float64() / float64(numberOfPayments)
+	if scheduleRate != 0 {
+		paymentPerSchedule = principal.Float64() * scheduleRate * (math.Pow(1+scheduleRate, float64(numberOfPayments))) /
+			(math.Pow(1+scheduleRate, float64(numberOfPayments)) - 1)
+	}
+
+	payment := money.Round(paymentPerSchedule, rounding)
+	if err := money.Check(principal, payment); err != nil {
+		return 0, err
+	}
 
-	if strings.ToUpper(c.Schedule) == AcceleratedBiweekly {
-		return math.Round(paymentPerSchedule*100) / 2 / 100, nil
+	if strings.ToUpper(schedule) == AcceleratedBiweekly {
+		return payment.Div(2, rounding), nil
 	}
 
-	return math.Round(paymentPerSchedule*100) / 100, nil
+	return payment, nil
 }
 
 // scheduleInterestRate returns the interest rate depending on the selected schedule.
-func (c *Calculator) scheduleInterestRate() (float64, error) {
-	np, err := c.paymentsPerYear()
+func scheduleInterestRate(annualInterestRate float64, schedule string) (float64, error) {
+	np, err := paymentsPerYear(schedule)
 	if err != nil {
 		return 0, err
 	}
-	return c.AnnualInterestRate / 100 / float64(np), nil
+	return annualInterestRate / 100 / float64(np), nil
 }
 
 // validateAmortizationPeriod returns true if Amortization period is between the amortization period allowed and a 5-year increment.
@@ -89,22 +235,91 @@ func (c *Calculator) validateAmortizationPeriod() error {
 	return nil
 }
 
+// term returns the number of years the interest rate is agreed for, 5 years when it is not provided.
+func (c *Calculator) term() (int, error) {
+	if c.Term == 0 {
+		return defaultTerm, nil
+	}
+	if c.Term > c.AmortizationPeriod {
+		return 0, ErrTermOutOfRange
+	}
+	return c.Term, nil
+}
+
 // calculateTotalMortgage performs the calculation of the CMHC mortgage value.
-func (c *Calculator) calculateTotalMortgage() (float64, error) {
+func (c *Calculator) calculateTotalMortgage() (money.Money, error) {
 	CMHC, err := c.calculateCMHC()
 	if err != nil {
 		return 0, err
 	}
-	return (c.PropertyPrice - c.DownPayment) + CMHC, nil
+	return c.loanAmount() + CMHC, nil
 }
 
 // calculateCMHC performs the calculation of the CMHC mortgage value.
-func (c *Calculator) calculateCMHC() (float64, error) {
+func (c *Calculator) calculateCMHC() (money.Money, error) {
+	if err := money.Check(money.FromFloat(c.PropertyPrice)); err != nil {
+		return 0, err
+	}
+
 	CMHCRate, err := c.calculateCMHCRate()
 	if err != nil {
 		return 0, err
 	}
-	return (c.PropertyPrice - c.DownPayment) * CMHCRate / 100, nil
+
+	rounding, err := money.ParseRoundingMode(c.Rounding)
+	if err != nil {
+		return 0, err
+	}
+	return c.loanAmount().MulRate(CMHCRate, 1, rounding), nil
+}
+
+// calculateCMHCPremiumTax performs the calculation of the provincial sales tax charged on the CMHC premium. The tax
+// can not be added to the mortgage so it is paid at closing.
+func (c *Calculator) calculateCMHCPremiumTax() (money.Money, error) {
+	premium, err := c.calculateCMHC()
+	if err != nil {
+		return 0, err
+	}
+
+	rules, err := province.Lookup(c.Province)
+	if err != nil {
+		return 0, err
+	}
+
+	rounding, err := money.ParseRoundingMode(c.Rounding)
+	if err != nil {
+		return 0, err
+	}
+	return premium.MulRate(rules.PremiumTaxRate(), 1, rounding), nil
+}
+
+// yearlyPropertyTax returns the yearly property tax provided or, when it is not, the one estimated for a BC property in
+// the municipality assessed at its price, less the basic home owner grant when it is a principal residence.
+func (c *Calculator) yearlyPropertyTax(propertyTax float64, principalResidence bool) (money.Money, error) {
+	if propertyTax > 0 || !c.inBritishColumbiaMunicipality() {
+		return money.FromFloat(propertyTax), nil
+	}
+
+	estimate, err := propertytax.Property{
+		AssessedValue:      c.PropertyPrice,
+		Municipality:       c.Municipality,
+		PrincipalResidence: principalResidence,
+		Rounding:           c.Rounding,
+	}.Estimate()
+	if err != nil {
+		return 0, err
+	}
+	return estimate.NetTax, nil
+}
+
+// inBritishColumbiaMunicipality returns whether the property is in a municipality of British Columbia.
+func (c *Calculator) inBritishColumbiaMunicipality() bool {
+	return c.Municipality != "" && (c.Province == "" || strings.ToUpper(c.Province) == province.BritishColumbia)
+}
+
+// loanAmount returns the amount borrowed before adding the CMHC insurance.
+func (c *Calculator) loanAmount() money.Money {
+	return money.FromFloat(c.PropertyPrice) - money.FromFloat(c.DownPayment)
 }
 
 // calculateCMHCRate performs the calculation of the percentage of the mortgage amount needed as insurance.
@@ -125,8 +340,8 @@ func (c *Calculator) calculateCMHCRate() (float64, error) {
 }
 
 // paymentsPerYear return the total amount of payments done on a year.
-func (c *Calculator) paymentsPerYear() (int, error) {
-	switch strings.ToUpper(c.Schedule) {
+func paymentsPerYear(schedule string) (int, error) {
+	switch strings.ToUpper(schedule) {
 	case AcceleratedBiweekly:
 		return 12, nil
 	case Biweekly:
@@ -137,15 +352,32 @@ func (c *Calculator) paymentsPerYear() (int, error) {
 	return 0, errInvalidSchedule
 }
 
-// totalNumberOfPayments return the total amount of payments done on the amortization period	.
-func (c *Calculator) totalNumberOfPayments() (int, error) {
-	switch strings.ToUpper(c.Schedule) {
-	case AcceleratedBiweekly:
-		return 12 * c.AmortizationPeriod, nil
-	case Biweekly:
-		return 26 * c.AmortizationPeriod, nil
+// paymentFrequency returns the number of payments actually made on a year, an accelerated biweekly schedule
+// pays half of the monthly payment every two weeks.
+func paymentFrequency(schedule string) (int, error) {
+	switch strings.ToUpper(schedule) {
+	case AcceleratedBiweekly, Biweekly:
+		return 26, nil
 	case Monthly:
-		return 12 * c.AmortizationPeriod, nil
+		return 12, nil
 	}
 	return 0, errInvalidSchedule
 }
+
+// totalNumberOfPayments return the total amount of payments done on the amortization period.
+func totalNumberOfPayments(schedule string, amortizationPeriod int) (int, error) {
+	np, err := paymentsPerYear(schedule)
+	if err != nil {
+		return 0, err
+	}
+	return np * amortizationPeriod, nil
+}
+
+// paymentsInMonths returns the number of payments done in the months, a partial payment period counts as a whole one.
+func paymentsInMonths(schedule string, months int) (int, error) {
+	np, err := paymentsPerYear(schedule)
+	if err != nil {
+		return 0, err
+	}
+	return (np*months + 11) / 12, nil
+}
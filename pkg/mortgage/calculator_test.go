@@ -1,6 +1,9 @@
 package mortgage
 
 import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/propertytax"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/province"
 	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
 	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
 	"testing"
@@ -177,7 +180,7 @@ func TestCalculateCMHC(t *testing.T) {
 
 		got, err := c.calculateCMHC()
 
-		AssertFloatValuesAndNilError(t, err, got, 4449.32)
+		AssertMoneyValuesAndNilError(t, err, got, 4449.32)
 	})
 
 	t.Run("when CMHC is not needed return 0, nil error", func(t *testing.T) {
@@ -188,7 +191,7 @@ func TestCalculateCMHC(t *testing.T) {
 
 		got, err := c.calculateCMHC()
 
-		AssertFloatValuesAndNilError(t, err, got, 0)
+		AssertMoneyValuesAndNilError(t, err, got, 0)
 	})
 
 	t.Run("when down payment is not large enough return a not large enough down payment error", func(t *testing.T) {
@@ -199,11 +202,77 @@ func TestCalculateCMHC(t *testing.T) {
 
 		got, err := c.calculateCMHC()
 
-		tests.AssertSameFloat(t, got, 0.0)
+		tests.AssertSameMoney(t, got, 0.0)
 		tests.AssertEqualErrors(t, err, ErrDownPaymentNotLargeEnough)
 	})
 }
 
+func TestCalculateCMHCPremiumTax(t *testing.T) {
+	t.Run("when the province charges sales tax on the premium return the tax, nil error", func(t *testing.T) {
+		c := Calculator{
+			PropertyPrice: 500000,
+			DownPayment:   25000,
+			Province:      province.Ontario,
+		}
+
+		got, err := c.calculateCMHCPremiumTax()
+
+		AssertMoneyValuesAndNilError(t, err, got, 1520)
+	})
+
+	t.Run("when the province does not charge sales tax on the premium return 0, nil error", func(t *testing.T) {
+		c := Calculator{
+			PropertyPrice: 500000,
+			DownPayment:   25000,
+		}
+
+		got, err := c.calculateCMHCPremiumTax()
+
+		AssertMoneyValuesAndNilError(t, err, got, 0)
+	})
+}
+
+func TestYearlyPropertyTax(t *testing.T) {
+	c := Calculator{PropertyPrice: 500000, DownPayment: 25000, Municipality: "Vancouver"}
+
+	t.Run("when the property tax is provided return it, nil error", func(t *testing.T) {
+		got, err := c.yearlyPropertyTax(2000, true)
+
+		AssertMoneyValuesAndNilError(t, err, got, 2000)
+	})
+
+	t.Run("when the property tax is not provided return the estimate less the home owner grant, nil error", func(t *testing.T) {
+		got, err := c.yearlyPropertyTax(0, true)
+
+		AssertMoneyValuesAndNilError(t, err, got, 831.80)
+	})
+
+	t.Run("when the property is not a principal residence return the estimate, nil error", func(t *testing.T) {
+		got, err := c.yearlyPropertyTax(0, false)
+
+		AssertMoneyValuesAndNilError(t, err, got, 1401.80)
+	})
+
+	t.Run("when the property is outside BC return 0, nil error", func(t *testing.T) {
+		toronto := c
+		toronto.Province = province.Ontario
+		toronto.Municipality = "Toronto"
+
+		got, err := toronto.yearlyPropertyTax(0, true)
+
+		AssertMoneyValuesAndNilError(t, err, got, 0)
+	})
+
+	t.Run("when the municipality is unknown return an unknown municipality error", func(t *testing.T) {
+		unknown := c
+		unknown.Municipality = "Atlantis"
+
+		_, err := unknown.yearlyPropertyTax(0, true)
+
+		tests.AssertEqualErrors(t, err, propertytax.ErrUnknownMunicipality)
+	})
+}
+
 func TestCalculateTotalMortgage(t *testing.T) {
 	t.Run("when a CMHC is needed the mortgage should equal the property price - payment + CMHC, nil error", func(t *testing.T) {
 		c := Calculator{
@@ -211,7 +280,7 @@ func TestCalculateTotalMortgage(t *testing.T) {
 			DownPayment:   12223,
 		}
 		got, err := c.calculateTotalMortgage()
-		AssertFloatValuesAndNilError(t, err, got, 115682.32)
+		AssertMoneyValuesAndNilError(t, err, got, 115682.32)
 	})
 
 	t.Run("when a CMHC is not needed the mortgage should equal the property price - payment, nil error", func(t *testing.T) {
@@ -220,7 +289,7 @@ func TestCalculateTotalMortgage(t *testing.T) {
 			DownPayment:   21000,
 		}
 		got, err := c.calculateTotalMortgage()
-		AssertFloatValuesAndNilError(t, err, got, c.PropertyPrice-c.DownPayment)
+		AssertMoneyValuesAndNilError(t, err, got, c.PropertyPrice-c.DownPayment)
 	})
 
 	t.Run("when down payment is not large enough return a not large enough down payment error", func(t *testing.T) {
@@ -229,7 +298,7 @@ func TestCalculateTotalMortgage(t *testing.T) {
 			DownPayment:   1000,
 		}
 		got, err := c.calculateTotalMortgage()
-		tests.AssertSameFloat(t, got, 0.0)
+		tests.AssertSameMoney(t, got, 0.0)
 		tests.AssertEqualErrors(t, err, ErrDownPaymentNotLargeEnough)
 	})
 }
@@ -239,7 +308,7 @@ func TestPaymentsPerYear(t *testing.T) {
 		c := Calculator{
 			Schedule: AcceleratedBiweekly,
 		}
-		got, err := c.paymentsPerYear()
+		got, err := paymentsPerYear(c.Schedule)
 		AssertIntValuesAndNilError(t, err, got, 12)
 	})
 
@@ -247,7 +316,7 @@ func TestPaymentsPerYear(t *testing.T) {
 		c := Calculator{
 			Schedule: Biweekly,
 		}
-		got, err := c.paymentsPerYear()
+		got, err := paymentsPerYear(c.Schedule)
 		AssertIntValuesAndNilError(t, err, got, 26)
 	})
 
@@ -255,7 +324,7 @@ func TestPaymentsPerYear(t *testing.T) {
 		c := Calculator{
 			Schedule: Monthly,
 		}
-		got, err := c.paymentsPerYear()
+		got, err := paymentsPerYear(c.Schedule)
 		AssertIntValuesAndNilError(t, err, got, 12)
 	})
 
@@ -263,7 +332,7 @@ func TestPaymentsPerYear(t *testing.T) {
 		c := Calculator{
 			Schedule: "Yearly",
 		}
-		got, err := c.totalNumberOfPayments()
+		got, err := totalNumberOfPayments(c.Schedule, c.AmortizationPeriod)
 		tests.AssertEqualErrors(t, err, errInvalidSchedule)
 		tests.AssertSameInt(t, got, 0)
 	})
@@ -275,7 +344,7 @@ func TestTotalNumberOfPayments(t *testing.T) {
 			Schedule:           AcceleratedBiweekly,
 			AmortizationPeriod: 5,
 		}
-		got, err := c.totalNumberOfPayments()
+		got, err := totalNumberOfPayments(c.Schedule, c.AmortizationPeriod)
 		AssertIntValuesAndNilError(t, err, got, 12*c.AmortizationPeriod)
 	})
 
@@ -284,7 +353,7 @@ func TestTotalNumberOfPayments(t *testing.T) {
 			Schedule:           Biweekly,
 			AmortizationPeriod: 5,
 		}
-		got, err := c.totalNumberOfPayments()
+		got, err := totalNumberOfPayments(c.Schedule, c.AmortizationPeriod)
 		AssertIntValuesAndNilError(t, err, got, 26*c.AmortizationPeriod)
 	})
 
@@ -293,7 +362,7 @@ func TestTotalNumberOfPayments(t *testing.T) {
 			Schedule:           AcceleratedBiweekly,
 			AmortizationPeriod: 5,
 		}
-		got, err := c.totalNumberOfPayments()
+		got, err := totalNumberOfPayments(c.Schedule, c.AmortizationPeriod)
 		AssertIntValuesAndNilError(t, err, got, 12*c.AmortizationPeriod)
 	})
 
@@ -302,12 +371,28 @@ func TestTotalNumberOfPayments(t *testing.T) {
 			Schedule:           "Yearly",
 			AmortizationPeriod: 5,
 		}
-		got, err := c.totalNumberOfPayments()
+		got, err := totalNumberOfPayments(c.Schedule, c.AmortizationPeriod)
 		tests.AssertEqualErrors(t, err, errInvalidSchedule)
 		tests.AssertSameInt(t, got, 0)
 	})
 }
 
+func TestSchedulePayment(t *testing.T) {
+	t.Run("when the rate is zero return the principal split in equal payments, nil error", func(t *testing.T) {
+		got, err := schedulePayment(money.FromFloat(300000), 0, 25, Monthly, money.HalfUp)
+
+		AssertMoneyValuesAndNilError(t, err, got, 1000)
+	})
+
+	t.Run("when the principal or the payment exceed the supported amounts return a error", func(t *testing.T) {
+		_, err := schedulePayment(money.FromFloat(1e20), 5, 25, Monthly, money.HalfUp)
+		tests.AssertEqualErrors(t, err, money.ErrAmountOutOfRange)
+
+		_, err = schedulePayment(money.FromFloat(300000), 1e9, 25, Monthly, money.HalfUp)
+		tests.AssertEqualErrors(t, err, money.ErrAmountOutOfRange)
+	})
+}
+
 func TestPaymentSchedule(t *testing.T) {
 	t.Run("should calculate the Monthly payment schedule ", func(t *testing.T) {
 		c := Calculator{
@@ -319,7 +404,7 @@ func TestPaymentSchedule(t *testing.T) {
 		}
 
 		got, err := c.PaymentSchedule()
-		AssertFloatValuesAndNilError(t, err, got, 1832.51)
+		AssertMoneyValuesAndNilError(t, err, got, 1832.51)
 	})
 
 	t.Run("should calculate the Biweekly payment schedule ", func(t *testing.T) {
@@ -332,7 +417,7 @@ func TestPaymentSchedule(t *testing.T) {
 		}
 
 		got, err := c.PaymentSchedule()
-		AssertFloatValuesAndNilError(t, err, got, 845.05)
+		AssertMoneyValuesAndNilError(t, err, got, 845.05)
 	})
 
 	t.Run("should calculate the accelerated Biweekly payment schedule ", func(t *testing.T) {
@@ -345,7 +430,46 @@ func TestPaymentSchedule(t *testing.T) {
 		}
 
 		got, err := c.PaymentSchedule()
-		AssertFloatValuesAndNilError(t, err, got, 916.255)
+		AssertMoneyValuesAndNilError(t, err, got, 916.26)
+	})
+
+	t.Run("should round the accelerated Biweekly payment half up by default", func(t *testing.T) {
+		c := Calculator{
+			PropertyPrice:      100000,
+			DownPayment:        5001,
+			AnnualInterestRate: 4.29,
+			AmortizationPeriod: 5,
+			Schedule:           AcceleratedBiweekly,
+		}
+
+		got, err := c.PaymentSchedule()
+		AssertMoneyValuesAndNilError(t, err, got, 916.25)
+	})
+
+	t.Run("should round the accelerated Biweekly payment half even when requested", func(t *testing.T) {
+		c := Calculator{
+			PropertyPrice:      100000,
+			DownPayment:        5001,
+			AnnualInterestRate: 4.29,
+			AmortizationPeriod: 5,
+			Schedule:           AcceleratedBiweekly,
+			Rounding:           "halfEven",
+		}
+
+		got, err := c.PaymentSchedule()
+		AssertMoneyValuesAndNilError(t, err, got, 916.24)
+	})
+
+	t.Run("should return a error if the rounding mode is not supported", func(t *testing.T) {
+		c := Calculator{
+			PropertyPrice:      100000,
+			DownPayment:        5000,
+			AnnualInterestRate: 4.29,
+			AmortizationPeriod: 5,
+			Schedule:           Monthly,
+			Rounding:           "ceiling",
+		}
+		AssertHandledScheduleErrors(t, &c, money.ErrInvalidRoundingMode)
 	})
 
 	t.Run("should return a error if Calculator does not pass validation check", func(t *testing.T) {
@@ -402,6 +526,143 @@ func TestPaymentSchedule(t *testing.T) {
 		}
 		AssertHandledScheduleErrors(t, &c, errInvalidSchedule)
 	})
+
+	t.Run("should return a error if the province is not supported", func(t *testing.T) {
+		c := Calculator{
+			PropertyPrice:      100000,
+			DownPayment:        5000,
+			AnnualInterestRate: 4.29,
+			AmortizationPeriod: 5,
+			Schedule:           Monthly,
+			Province:           "AB",
+		}
+		AssertHandledScheduleErrors(t, &c, province.ErrUnsupportedProvince)
+	})
+}
+
+func TestAmortizationSchedule(t *testing.T) {
+	c := Calculator{
+		PropertyPrice:      500000,
+		DownPayment:        100000,
+		AnnualInterestRate: 5,
+		AmortizationPeriod: 25,
+		Schedule:           Monthly,
+	}
+
+	t.Run("should amortize the mortgage over the amortization period", func(t *testing.T) {
+		got, err := c.AmortizationSchedule()
+		tests.AssertNilError(t, err)
+		tests.AssertSameInt(t, len(got), 300)
+		AssertPeriod(t, got[0], newPeriod(1, 2338.36, 1666.67, 671.69, 399328.31))
+		AssertPeriod(t, got[299], newPeriod(300, 2338.67, 9.70, 2328.97, 0))
+	})
+
+	t.Run("should pay off the mortgage earlier on an accelerated Biweekly schedule", func(t *testing.T) {
+		accelerated := c
+		accelerated.Schedule = AcceleratedBiweekly
+
+		got, err := accelerated.AmortizationSchedule()
+		tests.AssertNilError(t, err)
+		if len(got) >= 26*25 {
+			t.Errorf("got %d payments want less than %d", len(got), 26*25)
+		}
+		tests.AssertSameMoney(t, got[len(got)-1].Balance, 0)
+	})
+
+	t.Run("should return a error if the payment cannot be calculated", func(t *testing.T) {
+		invalid := c
+		invalid.AmortizationPeriod = 12
+
+		_, err := invalid.AmortizationSchedule()
+		tests.AssertEqualErrors(t, err, ErrPeriodNotAMultipleOfFive)
+	})
+}
+
+func TestSummary(t *testing.T) {
+	t.Run("should include the CMHC premium in the principal", func(t *testing.T) {
+		c := Calculator{
+			PropertyPrice:      100000,
+			DownPayment:        5000,
+			AnnualInterestRate: 4.29,
+			AmortizationPeriod: 5,
+			Schedule:           Monthly,
+		}
+
+		got, err := c.Summary()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.PaymentPerSchedule, 1832.51)
+		tests.AssertSameMoney(t, got.Principal, 98800)
+		tests.AssertSameMoney(t, got.CMHCPremium, 3800)
+		tests.AssertSameFloat(t, got.CMHCRate, 4)
+		tests.AssertSameInt(t, got.NumberOfPayments, 60)
+		tests.AssertSameMoney(t, got.TotalInterest, 11150.68)
+		tests.AssertSameMoney(t, got.TotalPaid, 109950.68)
+	})
+
+	t.Run("should keep the sales tax on the CMHC premium out of the principal", func(t *testing.T) {
+		c := Calculator{
+			PropertyPrice:      500000,
+			DownPayment:        25000,
+			AnnualInterestRate: 5,
+			AmortizationPeriod: 25,
+			Schedule:           Monthly,
+			Province:           province.Quebec,
+		}
+
+		got, err := c.Summary()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.CMHCPremium, 19000)
+		tests.AssertSameMoney(t, got.CMHCPremiumTax, 1710)
+		tests.AssertSameMoney(t, got.Principal, 494000)
+		tests.AssertSameMoney(t, got.CashToClose.CMHCPremiumTax, 1710)
+	})
+
+	t.Run("should count the payments actually made on an accelerated Biweekly schedule", func(t *testing.T) {
+		c := Calculator{
+			PropertyPrice:      100000,
+			DownPayment:        5000,
+			AnnualInterestRate: 4.29,
+			AmortizationPeriod: 5,
+			Schedule:           AcceleratedBiweekly,
+		}
+
+		got, err := c.Summary()
+		tests.AssertNilError(t, err)
+		tests.AssertSameInt(t, got.NumberOfPayments, 119)
+		tests.AssertSameMoney(t, got.TotalInterest, 10083.92)
+	})
+
+	t.Run("should split the interest paid over the term and the amortization", func(t *testing.T) {
+		c := Calculator{
+			PropertyPrice:      500000,
+			DownPayment:        100000,
+			AnnualInterestRate: 5,
+			AmortizationPeriod: 25,
+			Schedule:           Monthly,
+			Term:               3,
+		}
+
+		got, err := c.Summary()
+		tests.AssertNilError(t, err)
+		tests.AssertSameInt(t, got.Term, 3)
+		tests.AssertSameMoney(t, got.TermInterest, 58150.64)
+		tests.AssertSameMoney(t, got.TotalInterest, 301508.31)
+		tests.AssertSameMoney(t, got.TotalPaid, 701508.31)
+	})
+
+	t.Run("should return a error if the term is out of range", func(t *testing.T) {
+		c := Calculator{
+			PropertyPrice:      500000,
+			DownPayment:        100000,
+			AnnualInterestRate: 5,
+			AmortizationPeriod: 10,
+			Schedule:           Monthly,
+			Term:               15,
+		}
+
+		_, err := c.Summary()
+		tests.AssertEqualErrors(t, err, ErrTermOutOfRange)
+	})
 }
 
 func AssertHandledScheduleErrors(t testing.TB, c *Calculator, wantedErr error) {
@@ -418,3 +679,8 @@ func AssertFloatValuesAndNilError(t testing.TB, err error, got, want float64) {
 	tests.AssertSameFloat(t, got, want)
 	tests.AssertEqualErrors(t, err, nil)
 }
+
+func AssertMoneyValuesAndNilError(t testing.TB, err error, got money.Money, want float64) {
+	tests.AssertSameMoney(t, got, want)
+	tests.AssertEqualErrors(t, err, nil)
+}
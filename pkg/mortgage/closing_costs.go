@@ -0,0 +1,72 @@
+package mortgage
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/province"
+)
+
+// Limits of the minimum down payment tiers, in dollars.
+const (
+	minimumDownPaymentFirstTier = 500_000
+	maximumInsuredPrice         = 1_500_000
+)
+
+// minimumDownPayment returns the minimum down payment of a property: 5% of the first $500,000 and 10% of the rest, or
+// 20% of the whole price when it is too high to be insured. Being a minimum it is rounded up to the cent.
+func minimumDownPayment(price money.Money) money.Money {
+	firstTier := money.FromFloat(minimumDownPaymentFirstTier)
+	switch {
+	case price >= money.FromFloat(maximumInsuredPrice):
+		return percentRoundedUp(price, 20)
+	case price > firstTier:
+		return percentRoundedUp(firstTier, 5) + percentRoundedUp(price-firstTier, 10)
+	default:
+		return percentRoundedUp(price, 5)
+	}
+}
+
+// percentRoundedUp returns the percentage of the amount rounded up to the cent.
+func percentRoundedUp(amount money.Money, percent float64) money.Money {
+	portion := amount.MulRate(percent, 1, money.HalfUp)
+	if float64(portion)*100 < float64(amount)*percent {
+		portion++
+	}
+	return portion
+}
+
+// CashToClose holds the funds the buyer pays at closing, the CMHC premium is added to the mortgage but the sales tax
+// charged on it is not.
+type CashToClose struct {
+	DownPayment    money.Money            `json:"downPayment"`
+	CMHCPremiumTax money.Money            `json:"cmhcPremiumTax"`
+	TransferTaxes  province.TransferTaxes `json:"transferTaxes"`
+	Total          money.Money            `json:"total"`
+}
+
+// CashToClose returns the down payment, the sales tax on the CMHC premium and the transfer taxes paid at closing
+// according to the rules of the province.
+func (c Calculator) CashToClose() (CashToClose, error) {
+	premiumTax, err := c.calculateCMHCPremiumTax()
+	if err != nil {
+		return CashToClose{}, err
+	}
+
+	rules, err := province.Lookup(c.Province)
+	if err != nil {
+		return CashToClose{}, err
+	}
+
+	rounding, err := money.ParseRoundingMode(c.Rounding)
+	if err != nil {
+		return CashToClose{}, err
+	}
+
+	downPayment := money.FromFloat(c.DownPayment)
+	transferTaxes := rules.TransferTaxes(money.FromFloat(c.PropertyPrice), c.Municipality, c.FirstTimeBuyer, rounding)
+	return CashToClose{
+		DownPayment:    downPayment,
+		CMHCPremiumTax: premiumTax,
+		TransferTaxes:  transferTaxes,
+		Total:          downPayment + premiumTax + transferTaxes.Total,
+	}, nil
+}
@@ -0,0 +1,64 @@
+package mortgage
+
+import (
+	"fmt"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/province"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"testing"
+)
+
+func TestMinimumDownPayment(t *testing.T) {
+	cases := []struct {
+		price float64
+		want  float64
+	}{
+		{400000, 20000},
+		{500000, 25000},
+		{800000, 55000},
+		{1499999, 124999.90},
+		{1500000, 300000},
+		{123456.10, 6172.81},
+	}
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("should return %.2f for a price of %.2f", tc.want, tc.price), func(t *testing.T) {
+			tests.AssertSameMoney(t, minimumDownPayment(money.FromFloat(tc.price)), tc.want)
+		})
+	}
+}
+
+func TestCashToClose(t *testing.T) {
+	c := Calculator{
+		PropertyPrice:      500000,
+		DownPayment:        25000,
+		AnnualInterestRate: 5,
+		AmortizationPeriod: 25,
+		Schedule:           Monthly,
+	}
+
+	t.Run("should add up the down payment and the transfer taxes", func(t *testing.T) {
+		got, err := c.CashToClose()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.DownPayment, 25000)
+		tests.AssertSameMoney(t, got.CMHCPremiumTax, 0)
+		tests.AssertSameMoney(t, got.TransferTaxes.Total, 8000)
+		tests.AssertSameMoney(t, got.Total, 33000)
+	})
+
+	t.Run("should include the sales tax on the CMHC premium", func(t *testing.T) {
+		toronto := c
+		toronto.Province = province.Ontario
+		toronto.Municipality = "Toronto"
+		got, err := toronto.CashToClose()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.CMHCPremiumTax, 1520)
+		tests.AssertSameMoney(t, got.Total, 25000+1520+12950)
+	})
+
+	t.Run("should return an error for an unsupported province", func(t *testing.T) {
+		invalid := c
+		invalid.Province = "AB"
+		_, err := invalid.CashToClose()
+		tests.AssertEqualErrors(t, err, province.ErrUnsupportedProvince)
+	})
+}
@@ -0,0 +1,83 @@
+package mortgage
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"sync"
+)
+
+// errors for scenario comparisons
+var (
+	ErrBaselineOutOfRange = errors.New("baseline must be the index of one of the scenarios")
+)
+
+// Comparison holds the scenarios to compare side by side and the index of the scenario used as baseline.
+type Comparison struct {
+	Scenarios []Calculator `json:"scenarios" validate:"required,min=2,max=10,dive"`
+	Baseline  int          `json:"baseline" validate:"gte=0"`
+}
+
+// ScenarioResult holds the outputs of a scenario and its differences with the baseline.
+type ScenarioResult struct {
+	Scenario           int         `json:"scenario"`
+	Schedule           string      `json:"schedule"`
+	PaymentPerSchedule money.Money `json:"paymentPerSchedule"`
+	TermInterest       money.Money `json:"termInterest"`
+	TotalInterest      money.Money `json:"totalInterest"`
+	TotalPaid          money.Money `json:"totalPaid"`
+	PaymentDelta       money.Money `json:"paymentDelta"`
+	TotalInterestDelta money.Money `json:"totalInterestDelta"`
+	TotalPaidDelta     money.Money `json:"totalPaidDelta"`
+}
+
+// Compare calculates every scenario concurrently and returns their outputs along with the differences with the
+// baseline, a positive delta means the scenario costs more than the baseline.
+func (c Comparison) Compare() ([]ScenarioResult, error) {
+	err := validate.Check(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Baseline >= len(c.Scenarios) {
+		return nil, ErrBaselineOutOfRange
+	}
+
+	summaries := make([]Summary, len(c.Scenarios))
+	errs := make([]error, len(c.Scenarios))
+
+	var wg sync.WaitGroup
+	for i, scenario := range c.Scenarios {
+		wg.Add(1)
+		go func(i int, scenario Calculator) {
+			defer wg.Done()
+			summaries[i], errs[i] = scenario.Summary()
+		}(i, scenario)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("scenario %d: %w", i, err)
+		}
+	}
+
+	baseline := summaries[c.Baseline]
+	results := make([]ScenarioResult, len(summaries))
+	for i, s := range summaries {
+		results[i] = ScenarioResult{
+			Scenario:           i,
+			Schedule:           c.Scenarios[i].Schedule,
+			PaymentPerSchedule: s.PaymentPerSchedule,
+			TermInterest:       s.TermInterest,
+			TotalInterest:      s.TotalInterest,
+			TotalPaid:          s.TotalPaid,
+			PaymentDelta:       s.PaymentPerSchedule - baseline.PaymentPerSchedule,
+			TotalInterestDelta: s.TotalInterest - baseline.TotalInterest,
+			TotalPaidDelta:     s.TotalPaid - baseline.TotalPaid,
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,91 @@
+package mortgage
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"strings"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	fixed := Calculator{
+		PropertyPrice:      500000,
+		DownPayment:        100000,
+		AnnualInterestRate: 5,
+		AmortizationPeriod: 25,
+		Schedule:           Monthly,
+	}
+	longer := fixed
+	longer.AmortizationPeriod = 30
+	variable := fixed
+	variable.AnnualInterestRate = 4.5
+
+	c := Comparison{Scenarios: []Calculator{fixed, longer, variable}}
+
+	t.Run("should return the outputs of every scenario in order", func(t *testing.T) {
+		got, err := c.Compare()
+		tests.AssertNilError(t, err)
+		tests.AssertSameInt(t, len(got), 3)
+		for i, result := range got {
+			tests.AssertSameInt(t, result.Scenario, i)
+		}
+		tests.AssertSameMoney(t, got[0].PaymentPerSchedule, 2338.36)
+		tests.AssertSameMoney(t, got[0].TotalInterest, 301508.31)
+	})
+
+	t.Run("should return the differences with the baseline", func(t *testing.T) {
+		got, err := c.Compare()
+		tests.AssertNilError(t, err)
+
+		tests.AssertSameMoney(t, got[0].PaymentDelta, 0)
+		tests.AssertSameMoney(t, got[0].TotalInterestDelta, 0)
+		if got[1].PaymentDelta >= 0 || got[1].TotalInterestDelta <= 0 {
+			t.Errorf("got %+v want a lower payment and more interest than the baseline", got[1])
+		}
+		if got[2].PaymentDelta >= 0 || got[2].TotalInterestDelta >= 0 {
+			t.Errorf("got %+v want a lower payment and less interest than the baseline", got[2])
+		}
+		if got[2].TotalPaidDelta != got[2].TotalInterestDelta {
+			t.Errorf("got %s want %s", got[2].TotalPaidDelta, got[2].TotalInterestDelta)
+		}
+	})
+
+	t.Run("should compare against the chosen baseline", func(t *testing.T) {
+		baseline := c
+		baseline.Baseline = 2
+
+		got, err := baseline.Compare()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got[2].PaymentDelta, 0)
+		tests.AssertSameMoney(t, got[0].PaymentDelta, (got[0].PaymentPerSchedule - got[2].PaymentPerSchedule).Float64())
+	})
+
+	t.Run("should return a error if the baseline is out of range", func(t *testing.T) {
+		invalid := c
+		invalid.Baseline = 3
+
+		_, err := invalid.Compare()
+		tests.AssertEqualErrors(t, err, ErrBaselineOutOfRange)
+	})
+
+	t.Run("should return the scenario that failed", func(t *testing.T) {
+		invalid := Comparison{Scenarios: []Calculator{fixed, fixed}}
+		invalid.Scenarios[1].AmortizationPeriod = 12
+
+		_, err := invalid.Compare()
+		tests.AssertEqualErrors(t, err, ErrPeriodNotAMultipleOfFive)
+		if !strings.HasPrefix(err.Error(), "scenario 1") {
+			t.Errorf("got %s want the failed scenario", err)
+		}
+	})
+
+	t.Run("should return a error if there are less than two scenarios", func(t *testing.T) {
+		invalid := Comparison{Scenarios: []Calculator{fixed}}
+
+		_, err := invalid.Compare()
+		fieldError := validate.GetFieldErrors(err)[0]
+		if fieldError.Field != "scenarios" {
+			t.Errorf("expected scenarios got %s", fieldError.Field)
+		}
+	})
+}
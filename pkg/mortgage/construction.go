@@ -0,0 +1,127 @@
+package mortgage
+
+import (
+	"errors"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"sort"
+)
+
+// errors for construction mortgages
+var (
+	ErrDrawsDoNotMatchMortgage      = errors.New("draws must add up to the property price minus the down payment")
+	ErrInterestOnlyShorterThanDraws = errors.New("interest only periods must last at least until the final draw")
+	ErrInterestOnlyLongerThanPeriod = errors.New("interest only periods must not outlast the amortization period")
+)
+
+// Draw is an advance of funds made at the beginning of a payment period of a construction mortgage.
+type Draw struct {
+	Period int     `json:"period" validate:"required,gt=0"`
+	Amount float64 `json:"amount" validate:"required,gt=0"`
+}
+
+// Construction holds the properties needed to calculate a mortgage whose funds are advanced in draws, paying only
+// interest until it converts to an amortizing mortgage.
+type Construction struct {
+	Calculator
+	Draws               []Draw `json:"draws" validate:"required,min=1,dive"`
+	InterestOnlyPeriods int    `json:"interestOnlyPeriods,omitempty" validate:"gte=0"`
+}
+
+// ConstructionPeriod holds the advanced balance and the interest only payment of a single period.
+type ConstructionPeriod struct {
+	Number          int         `json:"number"`
+	Draw            money.Money `json:"draw"`
+	Balance         money.Money `json:"balance"`
+	InterestPayment money.Money `json:"interestPayment"`
+}
+
+// ConstructionSchedule holds the interest only phase of a construction mortgage and its amortizing payment once
+// converted.
+type ConstructionSchedule struct {
+	InterestOnly          []ConstructionPeriod `json:"interestOnly"`
+	TotalInterestOnly     money.Money          `json:"totalInterestOnly"`
+	PrincipalAtConversion money.Money          `json:"principalAtConversion"`
+	PaymentPerSchedule    money.Money          `json:"paymentPerSchedule"`
+}
+
+// DrawSchedule returns the interest only payments made while the funds are advanced and the amortizing payment once
+// the final draw converts the mortgage. The interest only phase lasts until the final draw unless a longer one is set,
+// never longer than the payments of the amortization period. The draws advance the loan amount only, the CMHC premium
+// is added to the principal at conversion so no interest only payment is made on it.
+func (c Construction) DrawSchedule() (ConstructionSchedule, error) {
+	err := validate.Check(c)
+	if err != nil {
+		return ConstructionSchedule{}, err
+	}
+
+	paymentPerSchedule, err := c.Calculator.PaymentSchedule()
+	if err != nil {
+		return ConstructionSchedule{}, err
+	}
+
+	draws := make([]Draw, len(c.Draws))
+	copy(draws, c.Draws)
+	sort.SliceStable(draws, func(i, j int) bool { return draws[i].Period < draws[j].Period })
+
+	var advanced money.Money
+	for _, d := range draws {
+		advanced += money.FromFloat(d.Amount)
+	}
+	if advanced != c.loanAmount() {
+		return ConstructionSchedule{}, ErrDrawsDoNotMatchMortgage
+	}
+
+	frequency, err := paymentFrequency(c.Schedule)
+	if err != nil {
+		return ConstructionSchedule{}, err
+	}
+
+	interestOnlyPeriods := draws[len(draws)-1].Period
+	if c.InterestOnlyPeriods != 0 {
+		if c.InterestOnlyPeriods < interestOnlyPeriods {
+			return ConstructionSchedule{}, ErrInterestOnlyShorterThanDraws
+		}
+		interestOnlyPeriods = c.InterestOnlyPeriods
+	}
+	if interestOnlyPeriods > frequency*c.AmortizationPeriod {
+		return ConstructionSchedule{}, ErrInterestOnlyLongerThanPeriod
+	}
+
+	rounding, err := money.ParseRoundingMode(c.Rounding)
+	if err != nil {
+		return ConstructionSchedule{}, err
+	}
+
+	principal, err := c.calculateTotalMortgage()
+	if err != nil {
+		return ConstructionSchedule{}, err
+	}
+
+	s := ConstructionSchedule{
+		InterestOnly:          make([]ConstructionPeriod, 0, interestOnlyPeriods),
+		PrincipalAtConversion: principal,
+		PaymentPerSchedule:    paymentPerSchedule,
+	}
+
+	var balance money.Money
+	next := 0
+	for n := 1; n <= interestOnlyPeriods; n++ {
+		var drawn money.Money
+		for ; next < len(draws) && draws[next].Period == n; next++ {
+			drawn += money.FromFloat(draws[next].Amount)
+		}
+		balance += drawn
+		interest := balance.MulRate(c.AnnualInterestRate, frequency, rounding)
+
+		s.InterestOnly = append(s.InterestOnly, ConstructionPeriod{
+			Number:          n,
+			Draw:            drawn,
+			Balance:         balance,
+			InterestPayment: interest,
+		})
+		s.TotalInterestOnly += interest
+	}
+
+	return s, nil
+}
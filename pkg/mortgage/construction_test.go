@@ -0,0 +1,121 @@
+package mortgage
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"testing"
+)
+
+func TestConstructionDrawSchedule(t *testing.T) {
+	c := Construction{
+		Calculator: Calculator{
+			PropertyPrice:      500000,
+			DownPayment:        100000,
+			AnnualInterestRate: 6,
+			AmortizationPeriod: 25,
+			Schedule:           Monthly,
+		},
+		Draws: []Draw{{Period: 4, Amount: 150000}, {Period: 1, Amount: 100000}, {Period: 6, Amount: 150000}},
+	}
+
+	t.Run("should pay only interest on the advanced balance until the final draw", func(t *testing.T) {
+		got, err := c.DrawSchedule()
+		tests.AssertNilError(t, err)
+		tests.AssertSameInt(t, len(got.InterestOnly), 6)
+
+		want := []ConstructionPeriod{
+			{Number: 1, Draw: money.FromFloat(100000), Balance: money.FromFloat(100000), InterestPayment: money.FromFloat(500)},
+			{Number: 2, Draw: money.FromFloat(0), Balance: money.FromFloat(100000), InterestPayment: money.FromFloat(500)},
+			{Number: 3, Draw: money.FromFloat(0), Balance: money.FromFloat(100000), InterestPayment: money.FromFloat(500)},
+			{Number: 4, Draw: money.FromFloat(150000), Balance: money.FromFloat(250000), InterestPayment: money.FromFloat(1250)},
+			{Number: 5, Draw: money.FromFloat(0), Balance: money.FromFloat(250000), InterestPayment: money.FromFloat(1250)},
+			{Number: 6, Draw: money.FromFloat(150000), Balance: money.FromFloat(400000), InterestPayment: money.FromFloat(2000)},
+		}
+		for i := range want {
+			if got.InterestOnly[i] != want[i] {
+				t.Errorf("got %+v want %+v", got.InterestOnly[i], want[i])
+			}
+		}
+		tests.AssertSameMoney(t, got.TotalInterestOnly, 6000)
+	})
+
+	t.Run("should return the amortizing payment once the mortgage converts", func(t *testing.T) {
+		got, err := c.DrawSchedule()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.PrincipalAtConversion, 400000)
+		tests.AssertSameMoney(t, got.PaymentPerSchedule, 2577.21)
+	})
+
+	t.Run("should extend the interest only phase when it is longer than the draws", func(t *testing.T) {
+		extended := c
+		extended.InterestOnlyPeriods = 8
+
+		got, err := extended.DrawSchedule()
+		tests.AssertNilError(t, err)
+		tests.AssertSameInt(t, len(got.InterestOnly), 8)
+		tests.AssertSameMoney(t, got.TotalInterestOnly, 10000)
+	})
+
+	t.Run("should return a error if the interest only phase ends before the final draw", func(t *testing.T) {
+		invalid := c
+		invalid.InterestOnlyPeriods = 5
+
+		_, err := invalid.DrawSchedule()
+		tests.AssertEqualErrors(t, err, ErrInterestOnlyShorterThanDraws)
+	})
+
+	t.Run("should add the insurance premium to the principal at conversion only", func(t *testing.T) {
+		insured := c
+		insured.DownPayment = 50000
+		insured.Draws = []Draw{{Period: 1, Amount: 200000}, {Period: 3, Amount: 250000}}
+
+		got, err := insured.DrawSchedule()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.InterestOnly[2].Balance, 450000)
+		tests.AssertSameMoney(t, got.PrincipalAtConversion, 463950)
+	})
+
+	t.Run("should return a error if the interest only phase exceeds the amortization period", func(t *testing.T) {
+		invalid := c
+		invalid.InterestOnlyPeriods = 25*12 + 1
+
+		_, err := invalid.DrawSchedule()
+		tests.AssertEqualErrors(t, err, ErrInterestOnlyLongerThanPeriod)
+	})
+
+	t.Run("should return a error if a draw is made after the amortization period", func(t *testing.T) {
+		invalid := c
+		invalid.Draws = []Draw{{Period: 1, Amount: 100000}, {Period: 1000000000, Amount: 300000}}
+
+		_, err := invalid.DrawSchedule()
+		tests.AssertEqualErrors(t, err, ErrInterestOnlyLongerThanPeriod)
+	})
+
+	t.Run("should return a error if the draws do not add up to the mortgage", func(t *testing.T) {
+		invalid := c
+		invalid.Draws = []Draw{{Period: 1, Amount: 100000}}
+
+		_, err := invalid.DrawSchedule()
+		tests.AssertEqualErrors(t, err, ErrDrawsDoNotMatchMortgage)
+	})
+
+	t.Run("should return a error if the calculator is not valid", func(t *testing.T) {
+		invalid := c
+		invalid.AmortizationPeriod = 7
+
+		_, err := invalid.DrawSchedule()
+		tests.AssertEqualErrors(t, err, ErrPeriodNotAMultipleOfFive)
+	})
+
+	t.Run("should return a error if there are no draws", func(t *testing.T) {
+		invalid := c
+		invalid.Draws = nil
+
+		_, err := invalid.DrawSchedule()
+		fieldError := validate.GetFieldErrors(err)[0]
+		if fieldError.Field != "draws" {
+			t.Errorf("expected draws got %s", fieldError.Field)
+		}
+	})
+}
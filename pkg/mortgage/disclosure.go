@@ -0,0 +1,131 @@
+package mortgage
+
+import (
+	"errors"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"math"
+)
+
+// irrIterations is the number of bisection steps used to solve the internal rate of return.
+const irrIterations = 200
+
+// errors for cost of borrowing disclosure
+var (
+	ErrLoanAmountTooSmall = errors.New("loan amount must be at least one cent")
+	ErrFeesExceedAdvance  = errors.New("fees must be lower than the loan amount")
+)
+
+// Disclosure holds the properties needed to disclose the cost of borrowing of a mortgage, the fees are paid by the
+// borrower at closing.
+type Disclosure struct {
+	Calculator
+	LenderFees float64 `json:"lenderFees" validate:"gte=0"`
+	BrokerFees float64 `json:"brokerFees" validate:"gte=0"`
+}
+
+// CostOfBorrowing holds the rates disclosed to the borrower next to the payment.
+type CostOfBorrowing struct {
+	PaymentPerSchedule   money.Money `json:"paymentPerSchedule"`
+	InsurancePremium     money.Money `json:"insurancePremium"`
+	Fees                 money.Money `json:"fees"`
+	EffectiveAnnualRate  float64     `json:"effectiveAnnualRate"`
+	AnnualPercentageRate float64     `json:"annualPercentageRate"`
+}
+
+// CostOfBorrowing returns the effective annual rate implied by compounding the interest on every payment and the
+// annual percentage rate, the rate that equates the amount received by the borrower, net of the insurance premium and
+// fees, to the payments made over the term plus the balance outstanding at its end.
+func (d Disclosure) CostOfBorrowing() (CostOfBorrowing, error) {
+	err := validate.Check(d)
+	if err != nil {
+		return CostOfBorrowing{}, err
+	}
+
+	paymentPerSchedule, err := d.PaymentSchedule()
+	if err != nil {
+		return CostOfBorrowing{}, err
+	}
+
+	term, err := d.term()
+	if err != nil {
+		return CostOfBorrowing{}, err
+	}
+
+	premium, err := d.calculateCMHC()
+	if err != nil {
+		return CostOfBorrowing{}, err
+	}
+
+	schedule, err := d.AmortizationSchedule()
+	if err != nil {
+		return CostOfBorrowing{}, err
+	}
+	if len(schedule) == 0 {
+		return CostOfBorrowing{}, ErrLoanAmountTooSmall
+	}
+
+	frequency, err := paymentFrequency(d.Schedule)
+	if err != nil {
+		return CostOfBorrowing{}, err
+	}
+
+	if n := frequency * term; n < len(schedule) {
+		schedule = schedule[:n]
+	}
+	flows := make([]float64, len(schedule))
+	for i, period := range schedule {
+		flows[i] = period.Payment.Float64()
+	}
+	flows[len(flows)-1] += schedule[len(schedule)-1].Balance.Float64()
+
+	fees := money.FromFloat(d.LenderFees) + money.FromFloat(d.BrokerFees)
+	if fees >= d.loanAmount() {
+		return CostOfBorrowing{}, ErrFeesExceedAdvance
+	}
+	periodRate := internalRateOfReturn((d.loanAmount() - fees).Float64(), flows)
+
+	return CostOfBorrowing{
+		PaymentPerSchedule:   paymentPerSchedule,
+		InsurancePremium:     premium,
+		Fees:                 fees,
+		EffectiveAnnualRate:  effectiveAnnualRate(d.AnnualInterestRate, frequency),
+		AnnualPercentageRate: math.Round(periodRate*float64(frequency)*100*100) / 100,
+	}, nil
+}
+
+// effectiveAnnualRate returns the annual rate equivalent to the nominal rate compounded frequency times a year. The
+// calculator compounds the interest as often as the payments are made, so the payment frequency is used.
+func effectiveAnnualRate(annualInterestRate float64, frequency int) float64 {
+	rate := math.Pow(1+annualInterestRate/100/float64(frequency), float64(frequency)) - 1
+	return math.Round(rate*100*100) / 100
+}
+
+// internalRateOfReturn returns the periodic rate that discounts the flows, paid at the end of every period, to the
+// amount advanced. The present value of the flows decreases as the rate grows so the rate is found by bisection, from
+// a range widened until it brackets the rate.
+func internalRateOfReturn(advance float64, flows []float64) float64 {
+	presentValue := func(rate float64) float64 {
+		var pv float64
+		discount := 1.0
+		for _, flow := range flows {
+			discount /= 1 + rate
+			pv += flow * discount
+		}
+		return pv
+	}
+
+	low, high := 0.0, 1.0
+	for presentValue(high) > advance {
+		low, high = high, high*2
+	}
+	for i := 0; i < irrIterations; i++ {
+		mid := (low + high) / 2
+		if presentValue(mid) > advance {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return (low + high) / 2
+}
@@ -0,0 +1,108 @@
+package mortgage
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"testing"
+)
+
+func TestCostOfBorrowing(t *testing.T) {
+	d := Disclosure{
+		Calculator: Calculator{
+			PropertyPrice:      500000,
+			DownPayment:        50000,
+			AnnualInterestRate: 5,
+			AmortizationPeriod: 25,
+			Schedule:           Monthly,
+		},
+		LenderFees: 1000,
+		BrokerFees: 2000,
+	}
+
+	t.Run("should include the insurance premium and fees in the annual percentage rate", func(t *testing.T) {
+		got, err := d.CostOfBorrowing()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.PaymentPerSchedule, 2712.21)
+		tests.AssertSameMoney(t, got.InsurancePremium, 13950)
+		tests.AssertSameMoney(t, got.Fees, 3000)
+		tests.AssertSameFloat(t, got.EffectiveAnnualRate, 5.12)
+		tests.AssertSameFloat(t, got.AnnualPercentageRate, 5.89)
+	})
+
+	t.Run("should match the nominal rate when there are no premium nor fees", func(t *testing.T) {
+		noFees := d
+		noFees.DownPayment = 100000
+		noFees.LenderFees = 0
+		noFees.BrokerFees = 0
+
+		got, err := noFees.CostOfBorrowing()
+		tests.AssertNilError(t, err)
+		tests.AssertSameFloat(t, got.AnnualPercentageRate, 5)
+	})
+
+	t.Run("should not cap the annual percentage rate of very high rates", func(t *testing.T) {
+		high := d
+		high.AnnualInterestRate = 1500
+
+		got, err := high.CostOfBorrowing()
+		tests.AssertNilError(t, err)
+		if got.AnnualPercentageRate <= 1500 {
+			t.Errorf("got %f want above 1500", got.AnnualPercentageRate)
+		}
+	})
+
+	t.Run("should return a error if the term is longer than the amortization period", func(t *testing.T) {
+		invalid := d
+		invalid.Term = 30
+
+		_, err := invalid.CostOfBorrowing()
+		tests.AssertEqualErrors(t, err, ErrTermOutOfRange)
+	})
+
+	t.Run("should return a error if the calculator is not valid", func(t *testing.T) {
+		invalid := d
+		invalid.DownPayment = 1000
+
+		_, err := invalid.CostOfBorrowing()
+		tests.AssertEqualErrors(t, err, ErrDownPaymentNotLargeEnough)
+	})
+
+	t.Run("should return a error if the loan amount is less than a cent", func(t *testing.T) {
+		invalid := d
+		invalid.PropertyPrice = 100.004
+		invalid.DownPayment = 100.001
+
+		_, err := invalid.CostOfBorrowing()
+		tests.AssertEqualErrors(t, err, ErrLoanAmountTooSmall)
+	})
+
+	t.Run("should return a error if the fees are not lower than the loan amount", func(t *testing.T) {
+		invalid := d
+		invalid.LenderFees = 500000
+
+		_, err := invalid.CostOfBorrowing()
+		tests.AssertEqualErrors(t, err, ErrFeesExceedAdvance)
+	})
+}
+
+func TestEffectiveAnnualRate(t *testing.T) {
+	t.Run("should compound the nominal rate on every payment", func(t *testing.T) {
+		tests.AssertSameFloat(t, effectiveAnnualRate(4.29, 12), 4.38)
+		tests.AssertSameFloat(t, effectiveAnnualRate(12, 12), 12.68)
+	})
+}
+
+func TestInternalRateOfReturn(t *testing.T) {
+	t.Run("should return the rate that discounts the flows to the advance", func(t *testing.T) {
+		got := internalRateOfReturn(1000, []float64{1100})
+		if got < 0.0999999 || got > 0.1000001 {
+			t.Errorf("got %f want 0.1", got)
+		}
+	})
+
+	t.Run("should return rates above 100% per period", func(t *testing.T) {
+		got := internalRateOfReturn(1000, []float64{5000})
+		if got < 3.9999999 || got > 4.0000001 {
+			t.Errorf("got %f want 4", got)
+		}
+	})
+}
@@ -0,0 +1,163 @@
+package mortgage
+
+import (
+	"errors"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+)
+
+// Limits of the registered plans a down payment can be withdrawn from, per buyer and in dollars. The Home Buyers' Plan
+// limit of $25,000 was raised to $35,000 on March 19, 2019 and to $60,000 on April 16, 2024.
+const (
+	homeBuyersPlanLimit           = 60_000
+	homeBuyersPlanLimit2019       = 35_000
+	homeBuyersPlanLimitBefore2019 = 25_000
+	homeBuyersPlanRepaymentYears  = 15
+	fhsaLifetimeLimit             = 40_000
+)
+
+// Withdrawals made from the first to the last of these years start being repaid the fifth year after them instead of
+// the second.
+const (
+	homeBuyersPlanDeferralFromYear = 2022
+	homeBuyersPlanDeferralToYear   = 2025
+)
+
+// errors for down payment sources
+var (
+	ErrHomeBuyersPlanLimitExceeded = errors.New("rrsp withdrawal exceeds the home buyers' plan limit per buyer of " +
+		"the withdrawal year")
+	ErrDownPaymentSourcesMismatch = errors.New("down payment must add up to the down payment sources")
+)
+
+// DownPaymentSources holds the breakdown of the funds used as down payment. The RRSP withdrawal is made under the
+// Home Buyers' Plan in the withdrawal year, after the rule changes of that year, and the FHSA withdrawal is assumed to
+// come from deductible contributions.
+type DownPaymentSources struct {
+	Savings         float64 `json:"savings" validate:"gte=0"`
+	FHSA            float64 `json:"fhsa" validate:"gte=0"`
+	RRSP            float64 `json:"rrsp" validate:"gte=0"`
+	Gifts           float64 `json:"gifts" validate:"gte=0"`
+	Buyers          int     `json:"buyers,omitempty" validate:"gte=0"`
+	WithdrawalYear  int     `json:"withdrawalYear" validate:"required_with=RRSP"`
+	MarginalTaxRate float64 `json:"marginalTaxRate" validate:"gte=0,lt=100"`
+}
+
+// FundedPurchase holds a mortgage whose down payment comes from several sources, the down payment is the total of the
+// sources when it is not provided.
+type FundedPurchase struct {
+	Calculator
+	Sources DownPaymentSources `json:"downPaymentSources"`
+}
+
+// HomeBuyersPlanRepayment holds the amount repaid to the RRSP in a year and the balance left to repay.
+type HomeBuyersPlanRepayment struct {
+	Year    int         `json:"year"`
+	Amount  money.Money `json:"amount"`
+	Balance money.Money `json:"balance"`
+}
+
+// FundedPurchaseSummary holds the payment of the mortgage along with the Home Buyers' Plan repayments and the tax
+// deductions of the FHSA contributions.
+type FundedPurchaseSummary struct {
+	PaymentPerSchedule       money.Money               `json:"paymentPerSchedule"`
+	DownPayment              money.Money               `json:"downPayment"`
+	HomeBuyersPlanLimit      money.Money               `json:"homeBuyersPlanLimit"`
+	HomeBuyersPlanRepayments []HomeBuyersPlanRepayment `json:"homeBuyersPlanRepayments"`
+	FHSATaxBenefit           money.Money               `json:"fhsaTaxBenefit"`
+}
+
+// Summary returns the payment of the mortgage, the yearly repayments of the RRSP withdrawal, due over 15 years, and the
+// tax saved by deducting the FHSA contributions at the marginal tax rate.
+func (f FundedPurchase) Summary() (FundedPurchaseSummary, error) {
+	total := money.FromFloat(f.Sources.Savings) + money.FromFloat(f.Sources.FHSA) + money.FromFloat(f.Sources.RRSP) +
+		money.FromFloat(f.Sources.Gifts)
+	if f.DownPayment == 0 {
+		f.DownPayment = total.Float64()
+	}
+
+	err := validate.Check(f)
+	if err != nil {
+		return FundedPurchaseSummary{}, err
+	}
+
+	if money.FromFloat(f.DownPayment) != total {
+		return FundedPurchaseSummary{}, ErrDownPaymentSourcesMismatch
+	}
+
+	rounding, err := money.ParseRoundingMode(f.Rounding)
+	if err != nil {
+		return FundedPurchaseSummary{}, err
+	}
+
+	buyers := f.Sources.Buyers
+	if buyers == 0 {
+		buyers = 1
+	}
+
+	limit := homeBuyersPlanWithdrawalLimit(f.Sources.WithdrawalYear) * money.Money(buyers)
+	withdrawal := money.FromFloat(f.Sources.RRSP)
+	if withdrawal > limit {
+		return FundedPurchaseSummary{}, ErrHomeBuyersPlanLimitExceeded
+	}
+
+	payment, err := f.PaymentSchedule()
+	if err != nil {
+		return FundedPurchaseSummary{}, err
+	}
+
+	deductible := money.FromFloat(f.Sources.FHSA)
+	if fhsaLimit := money.FromFloat(fhsaLifetimeLimit) * money.Money(buyers); deductible > fhsaLimit {
+		deductible = fhsaLimit
+	}
+
+	return FundedPurchaseSummary{
+		PaymentPerSchedule:       payment,
+		DownPayment:              total,
+		HomeBuyersPlanLimit:      limit,
+		HomeBuyersPlanRepayments: homeBuyersPlanRepayments(withdrawal, f.Sources.WithdrawalYear, rounding),
+		FHSATaxBenefit:           deductible.MulRate(f.Sources.MarginalTaxRate, 1, rounding),
+	}, nil
+}
+
+// homeBuyersPlanWithdrawalLimit returns the Home Buyers' Plan limit per buyer of the withdrawals made in the year.
+func homeBuyersPlanWithdrawalLimit(withdrawalYear int) money.Money {
+	switch {
+	case withdrawalYear < 2019:
+		return money.FromFloat(homeBuyersPlanLimitBefore2019)
+	case withdrawalYear < 2024:
+		return money.FromFloat(homeBuyersPlanLimit2019)
+	}
+	return money.FromFloat(homeBuyersPlanLimit)
+}
+
+// homeBuyersPlanFirstRepaymentYear returns the first year the withdrawal made in the year is repaid, the second year
+// after it or the fifth one for the withdrawals made from 2022 to 2025.
+func homeBuyersPlanFirstRepaymentYear(withdrawalYear int) int {
+	if withdrawalYear >= homeBuyersPlanDeferralFromYear && withdrawalYear <= homeBuyersPlanDeferralToYear {
+		return withdrawalYear + 5
+	}
+	return withdrawalYear + 2
+}
+
+// homeBuyersPlanRepayments returns the yearly repayments of the withdrawal in equal parts, the last one is adjusted so
+// the repayments add up to the withdrawal.
+func homeBuyersPlanRepayments(withdrawal money.Money, withdrawalYear int,
+	rounding money.RoundingMode) []HomeBuyersPlanRepayment {
+	if withdrawal == 0 {
+		return nil
+	}
+
+	amount := withdrawal.Div(homeBuyersPlanRepaymentYears, rounding)
+	balance := withdrawal
+	first := homeBuyersPlanFirstRepaymentYear(withdrawalYear)
+	repayments := make([]HomeBuyersPlanRepayment, homeBuyersPlanRepaymentYears)
+	for i := range repayments {
+		if i == len(repayments)-1 {
+			amount = balance
+		}
+		balance -= amount
+		repayments[i] = HomeBuyersPlanRepayment{Year: first + i, Amount: amount, Balance: balance}
+	}
+	return repayments
+}
@@ -0,0 +1,148 @@
+package mortgage
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"testing"
+)
+
+func TestFundedPurchaseSummary(t *testing.T) {
+	f := FundedPurchase{
+		Calculator: Calculator{
+			PropertyPrice:      500000,
+			AnnualInterestRate: 5,
+			AmortizationPeriod: 25,
+			Schedule:           Monthly,
+		},
+		Sources: DownPaymentSources{
+			Savings:         20000,
+			FHSA:            40000,
+			RRSP:            30000,
+			Gifts:           10000,
+			WithdrawalYear:  2024,
+			MarginalTaxRate: 30,
+		},
+	}
+
+	t.Run("should use the total of the sources as down payment", func(t *testing.T) {
+		got, err := f.Summary()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.DownPayment, 100000)
+		tests.AssertSameMoney(t, got.PaymentPerSchedule, 2338.36)
+	})
+
+	t.Run("should repay a 2022 to 2025 rrsp withdrawal over 15 years from the fifth year after it", func(t *testing.T) {
+		got, err := f.Summary()
+		tests.AssertNilError(t, err)
+		tests.AssertSameInt(t, len(got.HomeBuyersPlanRepayments), 15)
+
+		first := got.HomeBuyersPlanRepayments[0]
+		tests.AssertSameInt(t, first.Year, 2029)
+		tests.AssertSameMoney(t, first.Amount, 2000)
+		tests.AssertSameMoney(t, first.Balance, 28000)
+
+		last := got.HomeBuyersPlanRepayments[14]
+		tests.AssertSameInt(t, last.Year, 2043)
+		tests.AssertSameMoney(t, last.Balance, 0)
+	})
+
+	t.Run("should repay the rrsp withdrawal from the second year after it outside of 2022 to 2025", func(t *testing.T) {
+		for withdrawalYear, want := range map[int]int{2021: 2023, 2026: 2028} {
+			other := f
+			other.Sources.WithdrawalYear = withdrawalYear
+			got, err := other.Summary()
+			tests.AssertNilError(t, err)
+			tests.AssertSameInt(t, got.HomeBuyersPlanRepayments[0].Year, want)
+		}
+	})
+
+	t.Run("should return the tax benefit of the fhsa contributions", func(t *testing.T) {
+		got, err := f.Summary()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.FHSATaxBenefit, 12000)
+
+		grown := f
+		grown.Sources.FHSA = 45000
+		grown.Sources.Savings = 15000
+		got, err = grown.Summary()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.FHSATaxBenefit, 12000)
+	})
+
+	t.Run("should return an error when the rrsp withdrawal exceeds the limit", func(t *testing.T) {
+		large := f
+		large.Sources.RRSP = 70000
+		large.Sources.Savings = 0
+		large.Sources.FHSA = 20000
+		_, err := large.Summary()
+		tests.AssertEqualErrors(t, err, ErrHomeBuyersPlanLimitExceeded)
+
+		large.Sources.Buyers = 2
+		got, err := large.Summary()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.HomeBuyersPlanLimit, 120000)
+	})
+
+	t.Run("should limit the rrsp withdrawal to the limit of the withdrawal year", func(t *testing.T) {
+		before := f
+		before.Sources.RRSP = 40000
+		before.Sources.Savings = 10000
+		before.Sources.FHSA = 40000
+		before.Sources.Gifts = 10000
+		got, err := before.Summary()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.HomeBuyersPlanLimit, 60000)
+
+		before.Sources.WithdrawalYear = 2023
+		_, err = before.Summary()
+		tests.AssertEqualErrors(t, err, ErrHomeBuyersPlanLimitExceeded)
+
+		before.Sources.RRSP = 35000
+		before.Sources.Savings = 15000
+		got, err = before.Summary()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.HomeBuyersPlanLimit, 35000)
+	})
+
+	t.Run("should return an error when the down payment does not match the sources", func(t *testing.T) {
+		mismatch := f
+		mismatch.DownPayment = 90000
+		_, err := mismatch.Summary()
+		tests.AssertEqualErrors(t, err, ErrDownPaymentSourcesMismatch)
+	})
+
+	t.Run("should return a validation error for a negative source before matching the sources", func(t *testing.T) {
+		invalid := f
+		invalid.DownPayment = 100000
+		invalid.Sources.Savings = -20000
+		_, err := invalid.Summary()
+		fieldErrors := validate.GetFieldErrors(err)
+		if len(fieldErrors) == 0 || fieldErrors[0].Field != "savings" {
+			t.Errorf("expected savings got %v", err)
+		}
+	})
+
+	t.Run("should return a validation error without the withdrawal year", func(t *testing.T) {
+		invalid := f
+		invalid.Sources.WithdrawalYear = 0
+		_, err := invalid.Summary()
+		fieldError := validate.GetFieldErrors(err)[0]
+		if fieldError.Field != "withdrawalYear" {
+			t.Errorf("expected withdrawalYear got %s", fieldError.Field)
+		}
+	})
+}
+
+func TestHomeBuyersPlanRepayments(t *testing.T) {
+	t.Run("should adjust the last repayment", func(t *testing.T) {
+		got := homeBuyersPlanRepayments(money.FromFloat(1000), 2024, money.HalfUp)
+		tests.AssertSameMoney(t, got[0].Amount, 66.67)
+		tests.AssertSameMoney(t, got[14].Amount, 66.62)
+		tests.AssertSameMoney(t, got[14].Balance, 0)
+	})
+
+	t.Run("should return no repayments without a withdrawal", func(t *testing.T) {
+		tests.AssertSameInt(t, len(homeBuyersPlanRepayments(0, 2024, money.HalfUp)), 0)
+	})
+}
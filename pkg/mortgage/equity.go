@@ -0,0 +1,97 @@
+package mortgage
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"math"
+	"time"
+)
+
+// Equity holds the properties needed to track the equity of the property as the mortgage is paid, the property value
+// grows at the yearly appreciation rate from the start date, when the mortgage is funded. The rate may be negative but
+// above -100%, the property can not lose its whole value, and is at most 100%.
+type Equity struct {
+	Calculator
+	StartDate        string  `json:"startDate" validate:"required,datetime=2006-01-02"`
+	AppreciationRate float64 `json:"appreciationRate" validate:"gt=-100,lte=100"`
+}
+
+// EquityPeriod holds the breakdown of a single payment along with the equity and loan to value after it.
+type EquityPeriod struct {
+	Period
+	Date          string      `json:"date"`
+	PropertyValue money.Money `json:"propertyValue"`
+	Equity        money.Money `json:"equity"`
+	LoanToValue   float64     `json:"loanToValue"`
+}
+
+// EquitySchedule holds the equity after every payment and the first payment after which the loan to value is under
+// 80%, when the mortgage may be refinanced without default insurance. The period is zero and the date the start date
+// when the loan to value is under 80% from the start, the date is empty when it never is.
+type EquitySchedule struct {
+	Periods                  []EquityPeriod `json:"periods"`
+	LoanToValueUnder80Period int            `json:"loanToValueUnder80Period"`
+	LoanToValueUnder80Date   string         `json:"loanToValueUnder80Date,omitempty"`
+}
+
+// EquitySchedule returns the amortization schedule with the property value, the equity and the loan to value after
+// every payment.
+func (e Equity) EquitySchedule() (EquitySchedule, error) {
+	err := validate.Check(e)
+	if err != nil {
+		return EquitySchedule{}, err
+	}
+
+	start, err := time.Parse(dateLayout, e.StartDate)
+	if err != nil {
+		return EquitySchedule{}, err
+	}
+
+	schedule, err := e.AmortizationSchedule()
+	if err != nil {
+		return EquitySchedule{}, err
+	}
+
+	frequency, err := paymentFrequency(e.Schedule)
+	if err != nil {
+		return EquitySchedule{}, err
+	}
+
+	rounding, err := money.ParseRoundingMode(e.Rounding)
+	if err != nil {
+		return EquitySchedule{}, err
+	}
+
+	principal, err := e.calculateTotalMortgage()
+	if err != nil {
+		return EquitySchedule{}, err
+	}
+
+	s := EquitySchedule{Periods: make([]EquityPeriod, len(schedule))}
+	if loanToValue(principal, money.FromFloat(e.PropertyPrice)) < maximumTotalLoanToValue {
+		s.LoanToValueUnder80Date = e.StartDate
+	}
+
+	for i, period := range schedule {
+		growth := math.Pow(1+e.AppreciationRate/100, float64(period.Number)/float64(frequency))
+		value := money.Round(e.PropertyPrice*growth, rounding)
+		if err := money.Check(value); err != nil {
+			return EquitySchedule{}, err
+		}
+		date := paymentDate(start, e.Schedule, period.Number).Format(dateLayout)
+
+		s.Periods[i] = EquityPeriod{
+			Period:        period,
+			Date:          date,
+			PropertyValue: value,
+			Equity:        value - period.Balance,
+			LoanToValue:   loanToValue(period.Balance, value),
+		}
+		if s.LoanToValueUnder80Date == "" && s.Periods[i].LoanToValue < maximumTotalLoanToValue {
+			s.LoanToValueUnder80Period = period.Number
+			s.LoanToValueUnder80Date = date
+		}
+	}
+
+	return s, nil
+}
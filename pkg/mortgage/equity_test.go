@@ -0,0 +1,115 @@
+package mortgage
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"testing"
+)
+
+func TestEquitySchedule(t *testing.T) {
+	c := Calculator{
+		PropertyPrice:      500000,
+		DownPayment:        50000,
+		AnnualInterestRate: 5,
+		AmortizationPeriod: 25,
+		Schedule:           Monthly,
+	}
+	e := Equity{Calculator: c, StartDate: "2024-01-15"}
+	schedule, _ := c.AmortizationSchedule()
+
+	t.Run("should return the equity and loan to value after every payment", func(t *testing.T) {
+		got, err := e.EquitySchedule()
+		tests.AssertNilError(t, err)
+		tests.AssertSameInt(t, len(got.Periods), len(schedule))
+
+		first := got.Periods[0]
+		AssertPeriod(t, first.Period, schedule[0])
+		if first.Date != "2024-02-15" {
+			t.Errorf("got %s want 2024-02-15", first.Date)
+		}
+		tests.AssertSameMoney(t, first.PropertyValue, 500000)
+		tests.AssertSameMoney(t, first.Equity, 500000-schedule[0].Balance.Float64())
+		tests.AssertSameFloat(t, first.LoanToValue, 92.63)
+	})
+
+	t.Run("should return the first payment after which the loan to value is under 80%", func(t *testing.T) {
+		got, err := e.EquitySchedule()
+		tests.AssertNilError(t, err)
+
+		n := got.LoanToValueUnder80Period
+		if n == 0 {
+			t.Fatalf("got no period under 80%%")
+		}
+		if got.Periods[n-1].LoanToValue >= 80 || got.Periods[n-2].LoanToValue < 80 {
+			t.Errorf("got period %d, want the first one under 80%%", n)
+		}
+		if got.LoanToValueUnder80Date != got.Periods[n-1].Date {
+			t.Errorf("got %s want %s", got.LoanToValueUnder80Date, got.Periods[n-1].Date)
+		}
+	})
+
+	t.Run("should reach 80% sooner when the property appreciates", func(t *testing.T) {
+		flat, err := e.EquitySchedule()
+		tests.AssertNilError(t, err)
+
+		appreciating := e
+		appreciating.AppreciationRate = 3
+		got, err := appreciating.EquitySchedule()
+		tests.AssertNilError(t, err)
+
+		tests.AssertSameMoney(t, got.Periods[11].PropertyValue, 515000)
+		if got.LoanToValueUnder80Period >= flat.LoanToValueUnder80Period {
+			t.Errorf("got period %d, want sooner than %d", got.LoanToValueUnder80Period, flat.LoanToValueUnder80Period)
+		}
+	})
+
+	t.Run("should return the start date when the loan to value is under 80% from the start", func(t *testing.T) {
+		conventional := e
+		conventional.DownPayment = 150000
+		got, err := conventional.EquitySchedule()
+		tests.AssertNilError(t, err)
+		tests.AssertSameInt(t, got.LoanToValueUnder80Period, 0)
+		if got.LoanToValueUnder80Date != "2024-01-15" {
+			t.Errorf("got %s want 2024-01-15", got.LoanToValueUnder80Date)
+		}
+	})
+
+	t.Run("should return a validation error for an invalid start date", func(t *testing.T) {
+		invalid := e
+		invalid.StartDate = "15/01/2024"
+		_, err := invalid.EquitySchedule()
+		fieldError := validate.GetFieldErrors(err)[0]
+		if fieldError.Field != "startDate" {
+			t.Errorf("expected startDate got %s", fieldError.Field)
+		}
+	})
+
+	t.Run("should return a validation error for an appreciation rate of -100% or lower", func(t *testing.T) {
+		invalid := e
+		invalid.AppreciationRate = -150
+		_, err := invalid.EquitySchedule()
+		fieldErrors := validate.GetFieldErrors(err)
+		if len(fieldErrors) == 0 || fieldErrors[0].Field != "appreciationRate" {
+			t.Errorf("expected appreciationRate got %v", err)
+		}
+	})
+
+	t.Run("should return a validation error for an appreciation rate above 100%", func(t *testing.T) {
+		invalid := e
+		invalid.AppreciationRate = 300
+		_, err := invalid.EquitySchedule()
+		fieldErrors := validate.GetFieldErrors(err)
+		if len(fieldErrors) == 0 || fieldErrors[0].Field != "appreciationRate" {
+			t.Errorf("expected appreciationRate got %v", err)
+		}
+	})
+
+	t.Run("should accept an appreciation rate of 100%", func(t *testing.T) {
+		bound := e
+		bound.AmortizationPeriod = 15
+		bound.AppreciationRate = 100
+		got, err := bound.EquitySchedule()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.Periods[11].PropertyValue, bound.PropertyPrice*2)
+	})
+}
@@ -0,0 +1,159 @@
+package mortgage
+
+import (
+	"errors"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"strings"
+	"time"
+)
+
+// Types of extra payments
+const (
+	PerPayment = "PERPAYMENT"
+	LumpSum    = "LUMPSUM"
+)
+
+// defaultPrepaymentPrivilege is the percentage of the payment or the principal that may be prepaid when the lender's
+// privileges are not provided.
+const defaultPrepaymentPrivilege = 20
+
+// errors for extra payments
+var (
+	ErrInvalidExtraPaymentMethod   = errors.New("method must be perPayment or lumpSum")
+	ErrTargetDateOutOfRange        = errors.New("target date must be after the first payment")
+	ErrPrepaymentPrivilegeExceeded = errors.New("the extra payment needed exceeds the prepayment privileges")
+)
+
+// ExtraPayment holds the properties needed to find the extra payment that pays off the mortgage by the target date.
+// The extra amount is either added to every payment, up to PaymentIncreaseLimit percent of the payment, or paid as a
+// lump sum on every anniversary, up to LumpSumLimit percent of the principal. Both limits are 20% when not provided.
+type ExtraPayment struct {
+	Calculator
+	StartDate            string  `json:"startDate" validate:"required,datetime=2006-01-02"`
+	TargetDate           string  `json:"targetDate" validate:"required,datetime=2006-01-02"`
+	Method               string  `json:"method" validate:"required"`
+	PaymentIncreaseLimit float64 `json:"paymentIncreaseLimit,omitempty" validate:"gte=0,lte=100"`
+	LumpSumLimit         float64 `json:"lumpSumLimit,omitempty" validate:"gte=0,lte=100"`
+}
+
+// ExtraPaymentPlan holds the extra amount needed, per payment or per year depending on the method, and the payoff it
+// achieves.
+type ExtraPaymentPlan struct {
+	Method             string      `json:"method"`
+	Extra              money.Money `json:"extra"`
+	PaymentPerSchedule money.Money `json:"paymentPerSchedule"`
+	NumberOfPayments   int         `json:"numberOfPayments"`
+	PayoffDate         string      `json:"payoffDate"`
+	TotalInterest      money.Money `json:"totalInterest"`
+	InterestSaved      money.Money `json:"interestSaved"`
+}
+
+// Solve returns the smallest extra amount that pays off the mortgage on or before the target date, the extra is zero
+// when the mortgage is already paid off by then.
+func (e ExtraPayment) Solve() (ExtraPaymentPlan, error) {
+	err := validate.Check(e)
+	if err != nil {
+		return ExtraPaymentPlan{}, err
+	}
+
+	method := strings.ToUpper(e.Method)
+	if method != PerPayment && method != LumpSum {
+		return ExtraPaymentPlan{}, ErrInvalidExtraPaymentMethod
+	}
+
+	start, err := time.Parse(dateLayout, e.StartDate)
+	if err != nil {
+		return ExtraPaymentPlan{}, err
+	}
+
+	target, err := time.Parse(dateLayout, e.TargetDate)
+	if err != nil {
+		return ExtraPaymentPlan{}, err
+	}
+
+	payment, err := e.PaymentSchedule()
+	if err != nil {
+		return ExtraPaymentPlan{}, err
+	}
+
+	schedule, err := e.AmortizationSchedule()
+	if err != nil {
+		return ExtraPaymentPlan{}, err
+	}
+
+	frequency, err := paymentFrequency(e.Schedule)
+	if err != nil {
+		return ExtraPaymentPlan{}, err
+	}
+
+	rounding, err := money.ParseRoundingMode(e.Rounding)
+	if err != nil {
+		return ExtraPaymentPlan{}, err
+	}
+
+	principal, err := e.calculateTotalMortgage()
+	if err != nil {
+		return ExtraPaymentPlan{}, err
+	}
+
+	payments := 0
+	for payments < len(schedule) && !paymentDate(start, e.Schedule, payments+1).After(target) {
+		payments++
+	}
+	if payments == 0 {
+		return ExtraPaymentPlan{}, ErrTargetDateOutOfRange
+	}
+
+	limit := payment.MulRate(privilege(e.PaymentIncreaseLimit), 1, rounding)
+	if method == LumpSum {
+		limit = principal.MulRate(privilege(e.LumpSumLimit), 1, rounding)
+	}
+
+	payoff := func(extra money.Money) []Period {
+		if method == LumpSum {
+			return prepay(principal, e.AnnualInterestRate, frequency, payment, 0, extra, len(schedule), rounding)
+		}
+		return prepay(principal, e.AnnualInterestRate, frequency, payment, extra, 0, len(schedule), rounding)
+	}
+
+	if len(payoff(limit)) > payments {
+		return ExtraPaymentPlan{}, ErrPrepaymentPrivilegeExceeded
+	}
+
+	// The number of payments decreases as the extra grows so the smallest extra is found by bisection.
+	var low, high money.Money = 0, limit
+	for low < high {
+		mid := (low + high) / 2
+		if len(payoff(mid)) <= payments {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+
+	prepaid := payoff(low)
+	interest := totalInterest(prepaid)
+	plan := ExtraPaymentPlan{
+		Method:             method,
+		Extra:              low,
+		PaymentPerSchedule: payment,
+		NumberOfPayments:   len(prepaid),
+		PayoffDate:         paymentDate(start, e.Schedule, len(prepaid)).Format(dateLayout),
+		TotalInterest:      interest,
+		InterestSaved:      totalInterest(schedule) - interest,
+	}
+	if method == PerPayment {
+		plan.PaymentPerSchedule += low
+	}
+
+	return plan, nil
+}
+
+// privilege returns the prepayment privilege percentage, the default one when it is not provided.
+func privilege(percent float64) float64 {
+	if percent == 0 {
+		return defaultPrepaymentPrivilege
+	}
+	return percent
+}
@@ -0,0 +1,91 @@
+package mortgage
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"testing"
+)
+
+func TestExtraPaymentSolve(t *testing.T) {
+	c := Calculator{
+		PropertyPrice:      500000,
+		DownPayment:        100000,
+		AnnualInterestRate: 5,
+		AmortizationPeriod: 25,
+		Schedule:           Monthly,
+	}
+	e := ExtraPayment{Calculator: c, StartDate: "2024-01-01", TargetDate: "2044-01-01", Method: "perPayment"}
+
+	t.Run("should return the smallest extra per payment that pays off by the target date", func(t *testing.T) {
+		got, err := e.Solve()
+		tests.AssertNilError(t, err)
+		if got.NumberOfPayments > 240 {
+			t.Errorf("got %d payments, want at most 240", got.NumberOfPayments)
+		}
+		if got.PayoffDate > "2044-01-01" {
+			t.Errorf("got payoff on %s, want by 2044-01-01", got.PayoffDate)
+		}
+		tests.AssertSameMoney(t, got.PaymentPerSchedule, 2338.36+got.Extra.Float64())
+
+		fewer := prepay(money.FromFloat(400000), 5, 12, money.FromFloat(2338.36), got.Extra-1, 0, 300, money.HalfUp)
+		if len(fewer) <= 240 {
+			t.Errorf("got %d payments with a cent less, want the smallest extra", len(fewer))
+		}
+	})
+
+	t.Run("should return the interest saved by the extra payments", func(t *testing.T) {
+		got, err := e.Solve()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.TotalInterest+got.InterestSaved, 301508.31)
+		if got.InterestSaved <= 0 {
+			t.Errorf("got %v, want interest saved", got.InterestSaved)
+		}
+	})
+
+	t.Run("should return the smallest yearly lump sum that pays off by the target date", func(t *testing.T) {
+		lumpSum := e
+		lumpSum.Method = LumpSum
+		lumpSum.TargetDate = "2039-01-01"
+		got, err := lumpSum.Solve()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.PaymentPerSchedule, 2338.36)
+		if got.NumberOfPayments > 180 || got.Extra <= 0 {
+			t.Errorf("got %d payments with %v, want at most 180", got.NumberOfPayments, got.Extra)
+		}
+	})
+
+	t.Run("should return no extra when the mortgage is paid off by the target date", func(t *testing.T) {
+		late := e
+		late.TargetDate = "2060-01-01"
+		got, err := late.Solve()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.Extra, 0)
+		tests.AssertSameInt(t, got.NumberOfPayments, 300)
+		if got.PayoffDate != "2049-01-01" {
+			t.Errorf("got %s want 2049-01-01", got.PayoffDate)
+		}
+	})
+
+	t.Run("should respect the prepayment privileges", func(t *testing.T) {
+		soon := e
+		soon.TargetDate = "2039-01-01"
+		_, err := soon.Solve()
+		tests.AssertEqualErrors(t, err, ErrPrepaymentPrivilegeExceeded)
+
+		soon.PaymentIncreaseLimit = 100
+		_, err = soon.Solve()
+		tests.AssertNilError(t, err)
+	})
+
+	t.Run("should return an error for invalid inputs", func(t *testing.T) {
+		invalid := e
+		invalid.Method = "weekly"
+		_, err := invalid.Solve()
+		tests.AssertEqualErrors(t, err, ErrInvalidExtraPaymentMethod)
+
+		invalid = e
+		invalid.TargetDate = "2024-01-15"
+		_, err = invalid.Solve()
+		tests.AssertEqualErrors(t, err, ErrTargetDateOutOfRange)
+	})
+}
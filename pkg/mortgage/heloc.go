@@ -0,0 +1,122 @@
+package mortgage
+
+import (
+	"errors"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+)
+
+const (
+	maximumHelocLoanToValue = 65
+	maximumTotalLoanToValue = 80
+)
+
+// errors for readvanceable mortgages
+var (
+	ErrLoanToValueExceeded = errors.New("amortizing balance and heloc balance exceed 80% of the property value")
+	ErrHelocLimitExceeded  = errors.New("heloc balance exceeds the available heloc limit")
+)
+
+// Readvanceable holds the properties needed to simulate a readvanceable mortgage, a mortgage whose HELOC limit grows
+// as the amortizing tranche is paid down.
+type Readvanceable struct {
+	PropertyValue     float64 `json:"propertyValue" validate:"required,gt=0"`
+	Schedule          string  `json:"schedule" validate:"required"`
+	Amortizing        Tranche `json:"amortizing"`
+	HelocInterestRate float64 `json:"helocInterestRate" validate:"required,gt=0"`
+	HelocBalance      float64 `json:"helocBalance" validate:"gte=0"`
+	ReborrowPrincipal bool    `json:"reborrowPrincipal"`
+	Rounding          string  `json:"rounding,omitempty"`
+}
+
+// ReadvanceablePeriod holds the state of a readvanceable mortgage after a single payment.
+type ReadvanceablePeriod struct {
+	Number               int         `json:"number"`
+	AmortizingPayment    money.Money `json:"amortizingPayment"`
+	AmortizingBalance    money.Money `json:"amortizingBalance"`
+	HelocLimit           money.Money `json:"helocLimit"`
+	HelocBalance         money.Money `json:"helocBalance"`
+	AvailableHelocRoom   money.Money `json:"availableHelocRoom"`
+	HelocInterestPayment money.Money `json:"helocInterestPayment"`
+	TotalDebt            money.Money `json:"totalDebt"`
+}
+
+// Simulate returns the amortizing balance, the HELOC limit, balance and interest only payments after every payment of
+// the amortizing tranche. When ReborrowPrincipal is set the principal paid on the amortizing tranche is drawn from
+// the HELOC as soon as it becomes available.
+func (r Readvanceable) Simulate() ([]ReadvanceablePeriod, error) {
+	err := validate.Check(r)
+	if err != nil {
+		return nil, err
+	}
+
+	m := Mortgage{Schedule: r.Schedule, Tranches: []Tranche{r.Amortizing}}
+	err = m.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	rounding, err := money.ParseRoundingMode(r.Rounding)
+	if err != nil {
+		return nil, err
+	}
+
+	value := money.FromFloat(r.PropertyValue)
+	principal := money.FromFloat(r.Amortizing.Principal)
+	helocBalance := money.FromFloat(r.HelocBalance)
+	if principal+helocBalance > value.MulRate(maximumTotalLoanToValue, 1, rounding) {
+		return nil, ErrLoanToValueExceeded
+	}
+	if helocBalance > helocLimit(value, principal, rounding) {
+		return nil, ErrHelocLimitExceeded
+	}
+
+	schedule, err := r.Amortizing.amortizationSchedule(r.Schedule, rounding)
+	if err != nil {
+		return nil, err
+	}
+
+	frequency, err := paymentFrequency(r.Schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	periods := make([]ReadvanceablePeriod, 0, len(schedule))
+	for _, period := range schedule {
+		helocInterest := helocBalance.MulRate(r.HelocInterestRate, frequency, rounding)
+		limit := helocLimit(value, period.Balance, rounding)
+
+		if r.ReborrowPrincipal {
+			helocBalance += period.Principal
+			if helocBalance > limit {
+				helocBalance = limit
+			}
+		}
+
+		periods = append(periods, ReadvanceablePeriod{
+			Number:               period.Number,
+			AmortizingPayment:    period.Payment,
+			AmortizingBalance:    period.Balance,
+			HelocLimit:           limit,
+			HelocBalance:         helocBalance,
+			AvailableHelocRoom:   limit - helocBalance,
+			HelocInterestPayment: helocInterest,
+			TotalDebt:            period.Balance + helocBalance,
+		})
+	}
+
+	return periods, nil
+}
+
+// helocLimit returns the HELOC limit given the amortizing balance, the HELOC alone cannot exceed 65% of the property
+// value and along with the amortizing balance cannot exceed 80% of it.
+func helocLimit(value, amortizingBalance money.Money, rounding money.RoundingMode) money.Money {
+	limit := value.MulRate(maximumTotalLoanToValue, 1, rounding) - amortizingBalance
+	if maximum := value.MulRate(maximumHelocLoanToValue, 1, rounding); limit > maximum {
+		limit = maximum
+	}
+	if limit < 0 {
+		return 0
+	}
+	return limit
+}
@@ -0,0 +1,82 @@
+package mortgage
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"testing"
+)
+
+func TestReadvanceableSimulate(t *testing.T) {
+	r := Readvanceable{
+		PropertyValue:     500000,
+		Schedule:          Monthly,
+		Amortizing:        Tranche{Principal: 300000, AnnualInterestRate: 5, AmortizationPeriod: 25},
+		HelocInterestRate: 6,
+		HelocBalance:      50000,
+	}
+
+	t.Run("should grow the heloc limit as the amortizing tranche is paid down", func(t *testing.T) {
+		got, err := r.Simulate()
+		tests.AssertNilError(t, err)
+		tests.AssertSameInt(t, len(got), 300)
+		want := ReadvanceablePeriod{
+			Number:               1,
+			AmortizingPayment:    money.FromFloat(1753.77),
+			AmortizingBalance:    money.FromFloat(299496.23),
+			HelocLimit:           money.FromFloat(100503.77),
+			HelocBalance:         money.FromFloat(50000),
+			AvailableHelocRoom:   money.FromFloat(50503.77),
+			HelocInterestPayment: money.FromFloat(250),
+			TotalDebt:            money.FromFloat(349496.23),
+		}
+		if got[0] != want {
+			t.Errorf("got %+v want %+v", got[0], want)
+		}
+	})
+
+	t.Run("should cap the heloc limit at 65% of the property value", func(t *testing.T) {
+		got, err := r.Simulate()
+		tests.AssertNilError(t, err)
+		last := got[len(got)-1]
+		tests.AssertSameMoney(t, last.AmortizingBalance, 0)
+		tests.AssertSameMoney(t, last.HelocLimit, 325000)
+		tests.AssertSameMoney(t, last.AvailableHelocRoom, 275000)
+	})
+
+	t.Run("should draw the principal paid from the heloc when reborrowing", func(t *testing.T) {
+		reborrow := r
+		reborrow.ReborrowPrincipal = true
+
+		got, err := reborrow.Simulate()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got[0].HelocBalance, 50503.77)
+		tests.AssertSameMoney(t, got[0].TotalDebt, 350000)
+		tests.AssertSameMoney(t, got[1].HelocInterestPayment, 252.52)
+		tests.AssertSameMoney(t, got[len(got)-1].HelocBalance, 325000)
+	})
+
+	t.Run("should return a error if the balances exceed 80% of the property value", func(t *testing.T) {
+		invalid := r
+		invalid.HelocBalance = 150000
+
+		_, err := invalid.Simulate()
+		tests.AssertEqualErrors(t, err, ErrLoanToValueExceeded)
+	})
+
+	t.Run("should return a error if the heloc balance exceeds 65% of the property value", func(t *testing.T) {
+		invalid := r
+		invalid.Amortizing.Principal = 10000
+		invalid.HelocBalance = 330000
+
+		_, err := invalid.Simulate()
+		tests.AssertEqualErrors(t, err, ErrHelocLimitExceeded)
+	})
+
+	t.Run("should return a error if the amortizing tranche is not valid", func(t *testing.T) {
+		invalid := r
+		invalid.Amortizing.AmortizationPeriod = 40
+
+		_, err := invalid.Simulate()
+		tests.AssertEqualErrors(t, err, ErrTrancheAmortizationOutOfRange)
+	})
+}
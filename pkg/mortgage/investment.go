@@ -0,0 +1,109 @@
+package mortgage
+
+import (
+	"errors"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"math"
+)
+
+// minimumInvestmentDownPayment is the minimum down payment percentage of a property that is not occupied by its owner.
+const minimumInvestmentDownPayment = 20
+
+// errors for investment properties
+var (
+	ErrInvestmentDownPaymentTooLow = errors.New("down payment is lower than the minimum 20% of the price of a rental property")
+)
+
+// Investment holds the properties needed to analyze the cash flow of a rental property bought with the mortgage. The
+// rent and strata fees are monthly amounts, the property tax, insurance and maintenance are yearly amounts and the
+// property management fee is a percentage of the rent collected.
+type Investment struct {
+	Calculator
+	MonthlyRent       float64 `json:"monthlyRent" validate:"required,gt=0"`
+	VacancyRate       float64 `json:"vacancyRate" validate:"gte=0,lte=100"`
+	ManagementRate    float64 `json:"managementRate" validate:"gte=0,lte=100"`
+	PropertyTax       float64 `json:"propertyTax" validate:"gte=0"`
+	Insurance         float64 `json:"insurance" validate:"gte=0"`
+	StrataFees        float64 `json:"strataFees" validate:"gte=0"`
+	Maintenance       float64 `json:"maintenance" validate:"gte=0"`
+	OtherClosingCosts float64 `json:"otherClosingCosts" validate:"gte=0"`
+}
+
+// InvestmentAnalysis holds the yearly income, expenses and cash flow of a rental property and its returns.
+type InvestmentAnalysis struct {
+	PaymentPerSchedule  money.Money `json:"paymentPerSchedule"`
+	GrossRent           money.Money `json:"grossRent"`
+	VacancyLoss         money.Money `json:"vacancyLoss"`
+	OperatingExpenses   money.Money `json:"operatingExpenses"`
+	NetOperatingIncome  money.Money `json:"netOperatingIncome"`
+	DebtService         money.Money `json:"debtService"`
+	CashFlow            money.Money `json:"cashFlow"`
+	CashInvested        money.Money `json:"cashInvested"`
+	CapRate             float64     `json:"capRate"`
+	CashOnCashReturn    float64     `json:"cashOnCashReturn"`
+	DebtServiceCoverage float64     `json:"debtServiceCoverage"`
+}
+
+// Analyze returns the first year cash flow of the rental property, its cap rate (the net operating income over the
+// price), its cash on cash return (the cash flow over the cash invested at closing) and its debt service coverage
+// ratio (the net operating income over the mortgage payments).
+func (i Investment) Analyze() (InvestmentAnalysis, error) {
+	err := validate.Check(i)
+	if err != nil {
+		return InvestmentAnalysis{}, err
+	}
+
+	if i.DownPayment*100 < i.PropertyPrice*minimumInvestmentDownPayment {
+		return InvestmentAnalysis{}, ErrInvestmentDownPaymentTooLow
+	}
+
+	payment, err := i.PaymentSchedule()
+	if err != nil {
+		return InvestmentAnalysis{}, err
+	}
+
+	frequency, err := paymentFrequency(i.Schedule)
+	if err != nil {
+		return InvestmentAnalysis{}, err
+	}
+
+	rounding, err := money.ParseRoundingMode(i.Rounding)
+	if err != nil {
+		return InvestmentAnalysis{}, err
+	}
+
+	cashToClose, err := i.CashToClose()
+	if err != nil {
+		return InvestmentAnalysis{}, err
+	}
+
+	grossRent := money.FromFloat(i.MonthlyRent) * 12
+	vacancyLoss := grossRent.MulRate(i.VacancyRate, 1, rounding)
+	collected := grossRent - vacancyLoss
+	expenses := collected.MulRate(i.ManagementRate, 1, rounding) + money.FromFloat(i.PropertyTax) +
+		money.FromFloat(i.Insurance) + money.FromFloat(i.StrataFees)*12 + money.FromFloat(i.Maintenance)
+	netOperatingIncome := collected - expenses
+	debtService := payment * money.Money(frequency)
+	cashFlow := netOperatingIncome - debtService
+	cashInvested := cashToClose.Total + money.FromFloat(i.OtherClosingCosts)
+
+	return InvestmentAnalysis{
+		PaymentPerSchedule:  payment,
+		GrossRent:           grossRent,
+		VacancyLoss:         vacancyLoss,
+		OperatingExpenses:   expenses,
+		NetOperatingIncome:  netOperatingIncome,
+		DebtService:         debtService,
+		CashFlow:            cashFlow,
+		CashInvested:        cashInvested,
+		CapRate:             percentOf(netOperatingIncome, money.FromFloat(i.PropertyPrice)),
+		CashOnCashReturn:    percentOf(cashFlow, cashInvested),
+		DebtServiceCoverage: math.Round(float64(netOperatingIncome)/float64(debtService)*100) / 100,
+	}, nil
+}
+
+// percentOf returns the amount as a percentage of the total rounded to 2 decimals.
+func percentOf(amount, total money.Money) float64 {
+	return math.Round(float64(amount)/float64(total)*100*100) / 100
+}
@@ -0,0 +1,59 @@
+package mortgage
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"testing"
+)
+
+func TestInvestmentAnalyze(t *testing.T) {
+	i := Investment{
+		Calculator: Calculator{
+			PropertyPrice:      500000,
+			DownPayment:        100000,
+			AnnualInterestRate: 5,
+			AmortizationPeriod: 25,
+			Schedule:           Monthly,
+		},
+		MonthlyRent:    3000,
+		VacancyRate:    5,
+		ManagementRate: 8,
+		PropertyTax:    2000,
+		Insurance:      1000,
+		Maintenance:    1500,
+	}
+
+	t.Run("should return the yearly cash flow of the property", func(t *testing.T) {
+		got, err := i.Analyze()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.GrossRent, 36000)
+		tests.AssertSameMoney(t, got.VacancyLoss, 1800)
+		tests.AssertSameMoney(t, got.OperatingExpenses, 7236)
+		tests.AssertSameMoney(t, got.NetOperatingIncome, 26964)
+		tests.AssertSameMoney(t, got.DebtService, 28060.32)
+		tests.AssertSameMoney(t, got.CashFlow, -1096.32)
+	})
+
+	t.Run("should return the returns of the property", func(t *testing.T) {
+		got, err := i.Analyze()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.CashInvested, 108000)
+		tests.AssertSameFloat(t, got.CapRate, 5.39)
+		tests.AssertSameFloat(t, got.CashOnCashReturn, -1.02)
+		tests.AssertSameFloat(t, got.DebtServiceCoverage, 0.96)
+	})
+
+	t.Run("should count every payment of a biweekly schedule as debt service", func(t *testing.T) {
+		biweekly := i
+		biweekly.Schedule = AcceleratedBiweekly
+		got, err := biweekly.Analyze()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.DebtService, got.PaymentPerSchedule.Float64()*26)
+	})
+
+	t.Run("should return an error when the down payment is lower than 20%", func(t *testing.T) {
+		low := i
+		low.DownPayment = 99999
+		_, err := low.Analyze()
+		tests.AssertEqualErrors(t, err, ErrInvestmentDownPaymentTooLow)
+	})
+}
@@ -0,0 +1,157 @@
+package mortgage
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/propertytax"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"math"
+)
+
+// Ownership holds the costs of owning the property besides the mortgage. The property tax and home insurance are
+// yearly amounts, the strata fees and utilities are monthly amounts and they all grow with inflation. The maintenance
+// is a yearly percentage of the property value, which grows at the appreciation rate. The inflation and appreciation
+// rates are above -100% and at most 100%. The property tax of a BC property
+// is estimated from the mill rate of its municipality when it is not provided. The property is the principal residence
+// of its owner unless its occupancy says otherwise, in which case the vacancy taxes of its BC municipality are added.
+type Ownership struct {
+	Calculator
+	Years            int                    `json:"years" validate:"required,gt=0,lte=50"`
+	PropertyTax      float64                `json:"propertyTax" validate:"gte=0"`
+	StrataFees       float64                `json:"strataFees" validate:"gte=0"`
+	HomeInsurance    float64                `json:"homeInsurance" validate:"gte=0"`
+	Utilities        float64                `json:"utilities" validate:"gte=0"`
+	MaintenanceRate  float64                `json:"maintenanceRate" validate:"gte=0"`
+	InflationRate    float64                `json:"inflationRate" validate:"gt=-100,lte=100"`
+	AppreciationRate float64                `json:"appreciationRate" validate:"gt=-100,lte=100"`
+	Occupancy        *propertytax.Occupancy `json:"occupancy,omitempty"`
+}
+
+// OwnershipYear holds the costs paid in a single year of ownership and the equity held at its end.
+type OwnershipYear struct {
+	Year            int         `json:"year"`
+	MortgagePayment money.Money `json:"mortgagePayment"`
+	Interest        money.Money `json:"interest"`
+	PropertyTax     money.Money `json:"propertyTax"`
+	StrataFees      money.Money `json:"strataFees"`
+	HomeInsurance   money.Money `json:"homeInsurance"`
+	Utilities       money.Money `json:"utilities"`
+	Maintenance     money.Money `json:"maintenance"`
+	VacancyTaxes    money.Money `json:"vacancyTaxes"`
+	TotalCost       money.Money `json:"totalCost"`
+	PropertyValue   money.Money `json:"propertyValue"`
+	MortgageBalance money.Money `json:"mortgageBalance"`
+	Equity          money.Money `json:"equity"`
+	EquityBuilt     money.Money `json:"equityBuilt"`
+	LoanToValue     float64     `json:"loanToValue"`
+}
+
+// OwnershipProjection holds the yearly costs of ownership and their total.
+type OwnershipProjection struct {
+	Years     []OwnershipYear `json:"years"`
+	TotalCost money.Money     `json:"totalCost"`
+}
+
+// Projection returns the costs of owning the property every year along with the equity built, through the principal
+// paid and the appreciation, and the loan to value at the end of the year.
+func (o Ownership) Projection() (OwnershipProjection, error) {
+	err := validate.Check(o)
+	if err != nil {
+		return OwnershipProjection{}, err
+	}
+
+	schedule, err := o.AmortizationSchedule()
+	if err != nil {
+		return OwnershipProjection{}, err
+	}
+
+	frequency, err := paymentFrequency(o.Schedule)
+	if err != nil {
+		return OwnershipProjection{}, err
+	}
+
+	rounding, err := money.ParseRoundingMode(o.Rounding)
+	if err != nil {
+		return OwnershipProjection{}, err
+	}
+
+	principal, err := o.calculateTotalMortgage()
+	if err != nil {
+		return OwnershipProjection{}, err
+	}
+
+	principalResidence := o.Occupancy == nil || o.Occupancy.PrincipalResidence
+	propertyTax, err := o.yearlyPropertyTax(o.PropertyTax, principalResidence)
+	if err != nil {
+		return OwnershipProjection{}, err
+	}
+
+	value := money.FromFloat(o.PropertyPrice)
+	strata := money.FromFloat(o.StrataFees) * 12
+	insurance := money.FromFloat(o.HomeInsurance)
+	utilities := money.FromFloat(o.Utilities) * 12
+	equity := value - principal
+
+	projection := OwnershipProjection{Years: make([]OwnershipYear, o.Years)}
+	for i, year := range yearlySchedule(schedule, frequency, o.Years) {
+		maintenance := value.MulRate(o.MaintenanceRate, 1, rounding)
+		vacancyTaxes, err := o.vacancyTaxes(value, rounding)
+		if err != nil {
+			return OwnershipProjection{}, err
+		}
+		value += value.MulRate(o.AppreciationRate, 1, rounding)
+
+		y := OwnershipYear{
+			Year:            year.Number,
+			MortgagePayment: year.Payment,
+			Interest:        year.Interest,
+			PropertyTax:     propertyTax,
+			StrataFees:      strata,
+			HomeInsurance:   insurance,
+			Utilities:       utilities,
+			Maintenance:     maintenance,
+			VacancyTaxes:    vacancyTaxes,
+			TotalCost:       year.Payment + propertyTax + strata + insurance + utilities + maintenance + vacancyTaxes,
+			PropertyValue:   value,
+			MortgageBalance: year.Balance,
+			Equity:          value - year.Balance,
+			EquityBuilt:     value - year.Balance - equity,
+			LoanToValue:     loanToValue(year.Balance, value),
+		}
+		projection.Years[i] = y
+		projection.TotalCost += y.TotalCost
+		equity = y.Equity
+
+		propertyTax += propertyTax.MulRate(o.InflationRate, 1, rounding)
+		strata += strata.MulRate(o.InflationRate, 1, rounding)
+		insurance += insurance.MulRate(o.InflationRate, 1, rounding)
+		utilities += utilities.MulRate(o.InflationRate, 1, rounding)
+		err = money.Check(y.TotalCost, projection.TotalCost, value, y.EquityBuilt, propertyTax, strata, insurance, utilities)
+		if err != nil {
+			return OwnershipProjection{}, err
+		}
+	}
+
+	return projection, nil
+}
+
+// vacancyTaxes returns the yearly vacancy taxes of the property assessed at the value, zero when it is the principal
+// residence of its owner or it is not in a BC municipality.
+func (o Ownership) vacancyTaxes(value money.Money, rounding money.RoundingMode) (money.Money, error) {
+	if o.Occupancy == nil || !o.inBritishColumbiaMunicipality() {
+		return 0, nil
+	}
+
+	taxes, err := o.Occupancy.VacancyTaxes(value, o.Municipality, rounding)
+	if err != nil {
+		return 0, err
+	}
+	return taxes.Total, nil
+}
+
+// loanToValue returns the balance as a percentage of the property value rounded to 2 decimals.
+func loanToValue(balance, value money.Money) float64 {
+	if value <= 0 {
+		return 0
+	}
+	return math.Round(float64(balance)/float64(value)*100*100) / 100
+}
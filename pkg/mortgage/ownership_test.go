@@ -0,0 +1,140 @@
+package mortgage
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/propertytax"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"math"
+	"testing"
+)
+
+func TestOwnershipProjection(t *testing.T) {
+	c := Calculator{
+		PropertyPrice:      500000,
+		DownPayment:        100000,
+		AnnualInterestRate: 5,
+		AmortizationPeriod: 25,
+		Schedule:           Monthly,
+	}
+	o := Ownership{
+		Calculator:      c,
+		Years:           30,
+		PropertyTax:     2000,
+		StrataFees:      300,
+		HomeInsurance:   1200,
+		Utilities:       150,
+		MaintenanceRate: 1,
+	}
+	schedule, _ := c.AmortizationSchedule()
+
+	t.Run("should add up the yearly costs of ownership", func(t *testing.T) {
+		got, err := o.Projection()
+		tests.AssertNilError(t, err)
+		tests.AssertSameInt(t, len(got.Years), 30)
+
+		year := got.Years[0]
+		tests.AssertSameInt(t, year.Year, 1)
+		tests.AssertSameMoney(t, year.MortgagePayment, 2338.36*12)
+		tests.AssertSameMoney(t, year.StrataFees, 3600)
+		tests.AssertSameMoney(t, year.Utilities, 1800)
+		tests.AssertSameMoney(t, year.Maintenance, 5000)
+		tests.AssertSameMoney(t, year.TotalCost, 2338.36*12+2000+3600+1200+1800+5000)
+		tests.AssertSameMoney(t, got.Years[29].TotalCost, 2000+3600+1200+1800+5000)
+	})
+
+	t.Run("should return the equity built and the loan to value", func(t *testing.T) {
+		got, err := o.Projection()
+		tests.AssertNilError(t, err)
+
+		balance := schedule[11].Balance
+		year := got.Years[0]
+		tests.AssertSameMoney(t, year.MortgageBalance, balance.Float64())
+		tests.AssertSameMoney(t, year.Equity, 500000-balance.Float64())
+		tests.AssertSameMoney(t, year.EquityBuilt, 400000-balance.Float64())
+		tests.AssertSameFloat(t, year.LoanToValue, math.Round(balance.Float64()/500000*100*100)/100)
+
+		tests.AssertSameMoney(t, got.Years[29].Equity, 500000)
+		tests.AssertSameFloat(t, got.Years[29].LoanToValue, 0)
+	})
+
+	t.Run("should grow the costs with inflation and the value with appreciation", func(t *testing.T) {
+		growing := o
+		growing.InflationRate = 2
+		growing.AppreciationRate = 3
+		got, err := growing.Projection()
+		tests.AssertNilError(t, err)
+
+		year := got.Years[1]
+		tests.AssertSameMoney(t, year.PropertyTax, 2040)
+		tests.AssertSameMoney(t, year.StrataFees, 3672)
+		tests.AssertSameMoney(t, year.Maintenance, 5150)
+		tests.AssertSameMoney(t, year.PropertyValue, 530450)
+		tests.AssertSameMoney(t, year.EquityBuilt,
+			15450+schedule[11].Balance.Float64()-schedule[23].Balance.Float64())
+	})
+
+	t.Run("should add up the total cost of every year", func(t *testing.T) {
+		got, err := o.Projection()
+		tests.AssertNilError(t, err)
+
+		var total float64
+		for _, year := range got.Years {
+			total += year.TotalCost.Float64()
+		}
+		tests.AssertSameMoney(t, got.TotalCost, total)
+	})
+
+	t.Run("should estimate the property tax of the municipality when it is not provided", func(t *testing.T) {
+		vancouver := o
+		vancouver.PropertyTax = 0
+		vancouver.Municipality = "Vancouver"
+		got, err := vancouver.Projection()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.Years[0].PropertyTax, 831.80)
+	})
+
+	t.Run("should add the vacancy taxes of a property that is not the principal residence", func(t *testing.T) {
+		vacant := o
+		vacant.Municipality = "Vancouver"
+		vacant.AppreciationRate = 3
+		vacant.Occupancy = &propertytax.Occupancy{Residency: propertytax.Foreign}
+		got, err := vacant.Projection()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.Years[0].VacancyTaxes, 30000)
+		tests.AssertSameMoney(t, got.Years[1].VacancyTaxes, 30900)
+		tests.AssertSameMoney(t, got.Years[0].TotalCost, got.Years[0].MortgagePayment.Float64()+2000+3600+1200+1800+5000+30000)
+	})
+
+	t.Run("should not add vacancy taxes to a rented property", func(t *testing.T) {
+		rented := o
+		rented.Municipality = "Vancouver"
+		rented.Occupancy = &propertytax.Occupancy{MonthsRented: 12}
+		got, err := rented.Projection()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.Years[0].VacancyTaxes, 0)
+	})
+
+	t.Run("should return a validation error for rates out of range", func(t *testing.T) {
+		for field, set := range map[string]func(o *Ownership){
+			"appreciationRate": func(o *Ownership) { o.AppreciationRate = -300 },
+			"inflationRate":    func(o *Ownership) { o.InflationRate = 200 },
+		} {
+			invalid := o
+			set(&invalid)
+			_, err := invalid.Projection()
+			fieldErrors := validate.GetFieldErrors(err)
+			if len(fieldErrors) == 0 || fieldErrors[0].Field != field {
+				t.Errorf("expected %s got %v", field, err)
+			}
+		}
+	})
+
+	t.Run("should accept the rates at their upper bound", func(t *testing.T) {
+		bound := o
+		bound.Years = 10
+		bound.InflationRate = 100
+		bound.AppreciationRate = 100
+		_, err := bound.Projection()
+		tests.AssertNilError(t, err)
+	})
+}
@@ -0,0 +1,43 @@
+package mortgage
+
+import "math"
+
+// Port holds the properties needed to port an existing mortgage to a new property and borrow the additional funds
+// at the current rate (blend-and-increase).
+type Port struct {
+	Schedule string  `json:"schedule" validate:"required"`
+	Existing Tranche `json:"existing"`
+	Increase Tranche `json:"increase"`
+	Rounding string  `json:"rounding,omitempty"`
+}
+
+// Mortgage returns the ported mortgage as a mortgage holding the existing balance and the increase tranches.
+func (p Port) Mortgage() Mortgage {
+	existing := p.Existing
+	if existing.Name == "" {
+		existing.Name = "existing"
+	}
+
+	increase := p.Increase
+	if increase.Name == "" {
+		increase.Name = "increase"
+	}
+
+	return Mortgage{
+		Schedule: p.Schedule,
+		Tranches: []Tranche{existing, increase},
+		Rounding: p.Rounding,
+	}
+}
+
+// BlendedRate returns the interest rate of the ported mortgage weighted by the principal of each tranche.
+func (p Port) BlendedRate() float64 {
+	principal := p.Existing.Principal + p.Increase.Principal
+	if principal == 0 {
+		return 0
+	}
+
+	rate := (p.Existing.Principal*p.Existing.AnnualInterestRate + p.Increase.Principal*p.Increase.AnnualInterestRate) /
+		principal
+	return math.Round(rate*100) / 100
+}
@@ -0,0 +1,54 @@
+package mortgage
+
+import (
+	"testing"
+)
+
+func TestPortMortgage(t *testing.T) {
+	t.Run("should hold the existing balance and the increase as tranches", func(t *testing.T) {
+		p := Port{
+			Schedule: Monthly,
+			Existing: Tranche{Principal: 300000, AnnualInterestRate: 2.5, AmortizationPeriod: 20},
+			Increase: Tranche{Principal: 100000, AnnualInterestRate: 5, AmortizationPeriod: 25},
+		}
+
+		got := p.Mortgage()
+
+		if len(got.Tranches) != 2 || got.Tranches[0].Name != "existing" || got.Tranches[1].Name != "increase" {
+			t.Errorf("got %+v want the existing and increase tranches", got.Tranches)
+		}
+		if got.Schedule != Monthly {
+			t.Errorf("got %s want %s", got.Schedule, Monthly)
+		}
+	})
+
+	t.Run("should keep the tranche names when provided", func(t *testing.T) {
+		p := Port{
+			Existing: Tranche{Name: "5 year fixed"},
+			Increase: Tranche{Name: "top-up"},
+		}
+
+		got := p.Mortgage()
+
+		if got.Tranches[0].Name != "5 year fixed" || got.Tranches[1].Name != "top-up" {
+			t.Errorf("got %+v want the provided names", got.Tranches)
+		}
+	})
+}
+
+func TestPortBlendedRate(t *testing.T) {
+	t.Run("should weight the rates by the principal of each tranche", func(t *testing.T) {
+		p := Port{
+			Existing: Tranche{Principal: 300000, AnnualInterestRate: 2.5},
+			Increase: Tranche{Principal: 100000, AnnualInterestRate: 5},
+		}
+
+		AssertFloatValuesAndNilError(t, nil, p.BlendedRate(), 3.13)
+	})
+
+	t.Run("should return 0 when there is no principal", func(t *testing.T) {
+		p := Port{}
+
+		AssertFloatValuesAndNilError(t, nil, p.BlendedRate(), 0)
+	})
+}
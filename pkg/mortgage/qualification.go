@@ -0,0 +1,247 @@
+package mortgage
+
+import (
+	"errors"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"math"
+	"strings"
+)
+
+// Treatments of the rental income of a suite in the property
+const (
+	AddBack = "ADDBACK"
+	Offset  = "OFFSET"
+)
+
+// Debt service rules of insured mortgages, in percentages.
+const (
+	maximumGrossDebtService = 39
+	maximumTotalDebtService = 44
+	minimumQualifyingRate   = 5.25
+	stressTestBuffer        = 2
+	strataFeesInGDS         = 50
+	defaultRentalPercentage = 50
+)
+
+// errors for debt service qualification
+var (
+	ErrInvalidRentalTreatment = errors.New("rental treatment must be addBack or offset")
+)
+
+// Qualification holds the properties needed to qualify a borrower for the mortgage. The income and property tax are
+// yearly amounts, the income being at least a cent a month, the heating, strata fees, other debt payments and rental
+// income are monthly amounts. The rental income of a suite is either added to the income or offset against the housing
+// costs, at RentalPercentage percent of the rent, 50% when not provided. The property tax of a BC property is estimated
+// from the mill rate of its municipality when it is not provided.
+type Qualification struct {
+	Calculator
+	AnnualIncome        float64 `json:"annualIncome" validate:"required,gte=0.12"`
+	PropertyTax         float64 `json:"propertyTax" validate:"gte=0"`
+	HeatingCost         float64 `json:"heatingCost" validate:"gte=0"`
+	StrataFees          float64 `json:"strataFees" validate:"gte=0"`
+	OtherDebtPayments   float64 `json:"otherDebtPayments" validate:"gte=0"`
+	MonthlyRentalIncome float64 `json:"monthlyRentalIncome" validate:"gte=0"`
+	RentalTreatment     string  `json:"rentalTreatment,omitempty"`
+	RentalPercentage    float64 `json:"rentalPercentage,omitempty" validate:"gte=0,lte=100"`
+}
+
+// DebtService holds the debt service ratios of the borrower and the highest price they qualify for with the same down
+// payment.
+type DebtService struct {
+	GrossDebtService float64     `json:"grossDebtService"`
+	TotalDebtService float64     `json:"totalDebtService"`
+	Qualifies        bool        `json:"qualifies"`
+	MaximumPrice     money.Money `json:"maximumPrice"`
+}
+
+// QualificationResult holds the payment at the qualifying rate and the debt service of the borrower, along with the
+// debt service without the rental income when there is one.
+type QualificationResult struct {
+	QualifyingRate      float64      `json:"qualifyingRate"`
+	QualifyingPayment   money.Money  `json:"qualifyingPayment"`
+	DebtService         DebtService  `json:"debtService"`
+	WithoutRentalIncome *DebtService `json:"withoutRentalIncome,omitempty"`
+}
+
+// Qualify returns the gross debt service (GDS), the housing costs over the income, and the total debt service (TDS),
+// the housing costs and other debts over the income. The mortgage payment is stress tested at the highest of the
+// interest rate plus 2% and 5.25%, monthly. The maximum price assumes the property tax grows with the price.
+func (q Qualification) Qualify() (QualificationResult, error) {
+	err := validate.Check(q)
+	if err != nil {
+		return QualificationResult{}, err
+	}
+
+	_, err = q.rentalTreatment()
+	if err != nil {
+		return QualificationResult{}, err
+	}
+
+	_, err = q.PaymentSchedule()
+	if err != nil {
+		return QualificationResult{}, err
+	}
+
+	payment, err := q.qualifyingPayment()
+	if err != nil {
+		return QualificationResult{}, err
+	}
+
+	result := QualificationResult{QualifyingRate: q.qualifyingRate(), QualifyingPayment: payment}
+	result.DebtService, err = q.debtService()
+	if err != nil {
+		return QualificationResult{}, err
+	}
+
+	if q.MonthlyRentalIncome > 0 {
+		withoutRental := q
+		withoutRental.MonthlyRentalIncome = 0
+		debtService, err := withoutRental.debtService()
+		if err != nil {
+			return QualificationResult{}, err
+		}
+		result.WithoutRentalIncome = &debtService
+	}
+
+	return result, nil
+}
+
+// debtService returns the debt service ratios and the maximum price of the borrower.
+func (q Qualification) debtService() (DebtService, error) {
+	gds, tds, err := q.ratios()
+	if err != nil {
+		return DebtService{}, err
+	}
+
+	maximumPrice, err := q.maximumPrice()
+	if err != nil {
+		return DebtService{}, err
+	}
+
+	return DebtService{
+		GrossDebtService: math.Round(gds*100) / 100,
+		TotalDebtService: math.Round(tds*100) / 100,
+		Qualifies:        gds <= maximumGrossDebtService && tds <= maximumTotalDebtService,
+		MaximumPrice:     maximumPrice,
+	}, nil
+}
+
+// ratios returns the gross and total debt service ratios, half of the strata fees are counted as housing costs.
+func (q Qualification) ratios() (float64, float64, error) {
+	payment, err := q.qualifyingPayment()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	treatment, err := q.rentalTreatment()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	rounding, err := money.ParseRoundingMode(q.Rounding)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	propertyTax, err := q.yearlyPropertyTax(q.PropertyTax, true)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	income := money.FromFloat(q.AnnualIncome).Div(12, rounding)
+	housing := payment + propertyTax.Div(12, rounding) + money.FromFloat(q.HeatingCost) +
+		money.FromFloat(q.StrataFees).MulRate(strataFeesInGDS, 1, rounding)
+
+	rental := money.FromFloat(q.MonthlyRentalIncome).MulRate(q.rentalPercentage(), 1, rounding)
+	if treatment == Offset {
+		housing -= rental
+		if housing < 0 {
+			housing = 0
+		}
+	} else {
+		income += rental
+	}
+
+	gds := float64(housing) / float64(income) * 100
+	tds := float64(housing+money.FromFloat(q.OtherDebtPayments)) / float64(income) * 100
+	return gds, tds, nil
+}
+
+// maximumPrice returns the highest price, to the dollar, the borrower qualifies for with the same down payment, zero
+// when they do not qualify for any. The price can not require a down payment larger than the one provided.
+func (q Qualification) maximumPrice() (money.Money, error) {
+	qualifies := func(price int64) (bool, error) {
+		c := q
+		c.PropertyPrice = float64(price)
+		c.PropertyTax = q.PropertyTax * float64(price) / q.PropertyPrice
+		if minimumDownPayment(money.FromFloat(c.PropertyPrice)) > money.FromFloat(c.DownPayment) {
+			return false, nil
+		}
+		gds, tds, err := c.ratios()
+		return gds <= maximumGrossDebtService && tds <= maximumTotalDebtService, err
+	}
+
+	low := int64(math.Floor(q.DownPayment))
+	high := low * 20
+	for low < high {
+		mid := (low + high + 1) / 2
+		ok, err := qualifies(mid)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+
+	if low <= int64(math.Floor(q.DownPayment)) {
+		return 0, nil
+	}
+	return money.FromFloat(float64(low)), nil
+}
+
+// qualifyingPayment returns the monthly payment of the mortgage at the qualifying rate.
+func (q Qualification) qualifyingPayment() (money.Money, error) {
+	principal, err := q.calculateTotalMortgage()
+	if err != nil {
+		return 0, err
+	}
+
+	rounding, err := money.ParseRoundingMode(q.Rounding)
+	if err != nil {
+		return 0, err
+	}
+
+	return schedulePayment(principal, q.qualifyingRate(), q.AmortizationPeriod, Monthly, rounding)
+}
+
+// qualifyingRate returns the stress test rate, the highest of the interest rate plus 2% and 5.25%.
+func (q Qualification) qualifyingRate() float64 {
+	rate := q.AnnualInterestRate + stressTestBuffer
+	if rate < minimumQualifyingRate {
+		return minimumQualifyingRate
+	}
+	return rate
+}
+
+// rentalTreatment returns the treatment of the rental income, added to the income unless stated otherwise.
+func (q Qualification) rentalTreatment() (string, error) {
+	switch strings.ToUpper(q.RentalTreatment) {
+	case "", AddBack:
+		return AddBack, nil
+	case Offset:
+		return Offset, nil
+	}
+	return "", ErrInvalidRentalTreatment
+}
+
+// rentalPercentage returns the percentage of the rent counted, the default one when it is not provided.
+func (q Qualification) rentalPercentage() float64 {
+	if q.RentalPercentage == 0 {
+		return defaultRentalPercentage
+	}
+	return q.RentalPercentage
+}
@@ -0,0 +1,100 @@
+package mortgage
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"testing"
+)
+
+func TestQualify(t *testing.T) {
+	q := Qualification{
+		Calculator: Calculator{
+			PropertyPrice:      500000,
+			DownPayment:        100000,
+			AnnualInterestRate: 5,
+			AmortizationPeriod: 25,
+			Schedule:           Monthly,
+		},
+		AnnualIncome:      120000,
+		PropertyTax:       3000,
+		HeatingCost:       100,
+		OtherDebtPayments: 500,
+	}
+
+	t.Run("should return the debt service ratios at the qualifying rate", func(t *testing.T) {
+		got, err := q.Qualify()
+		tests.AssertNilError(t, err)
+		tests.AssertSameFloat(t, got.QualifyingRate, 7)
+		tests.AssertSameMoney(t, got.QualifyingPayment, 2827.12)
+		tests.AssertSameFloat(t, got.DebtService.GrossDebtService, 31.77)
+		tests.AssertSameFloat(t, got.DebtService.TotalDebtService, 36.77)
+		tests.AssertSameMoney(t, got.DebtService.MaximumPrice, 582893)
+		if !got.DebtService.Qualifies {
+			t.Errorf("got %v, want %v", got.DebtService.Qualifies, true)
+		}
+		if got.WithoutRentalIncome != nil {
+			t.Errorf("got %v, want nil", got.WithoutRentalIncome)
+		}
+	})
+
+	t.Run("should stress test at the minimum qualifying rate", func(t *testing.T) {
+		low := q
+		low.AnnualIncome = 60000
+		low.AnnualInterestRate = 2
+		got, err := low.Qualify()
+		tests.AssertNilError(t, err)
+		tests.AssertSameFloat(t, got.QualifyingRate, 5.25)
+		tests.AssertSameFloat(t, got.DebtService.TotalDebtService, 64.94)
+		tests.AssertSameMoney(t, got.DebtService.MaximumPrice, 338738)
+		if got.DebtService.Qualifies {
+			t.Errorf("got %v, want %v", got.DebtService.Qualifies, false)
+		}
+	})
+
+	t.Run("should add the rental income to the income", func(t *testing.T) {
+		rental := q
+		rental.MonthlyRentalIncome = 1500
+		got, err := rental.Qualify()
+		tests.AssertNilError(t, err)
+		tests.AssertSameFloat(t, got.DebtService.GrossDebtService, 29.55)
+		tests.AssertSameFloat(t, got.DebtService.TotalDebtService, 34.21)
+		tests.AssertSameMoney(t, got.DebtService.MaximumPrice, 620559)
+		tests.AssertSameFloat(t, got.WithoutRentalIncome.GrossDebtService, 31.77)
+		tests.AssertSameMoney(t, got.WithoutRentalIncome.MaximumPrice, 582893)
+	})
+
+	t.Run("should offset the rental income against the housing costs", func(t *testing.T) {
+		rental := q
+		rental.MonthlyRentalIncome = 1500
+		rental.RentalTreatment = "offset"
+		got, err := rental.Qualify()
+		tests.AssertNilError(t, err)
+		tests.AssertSameFloat(t, got.DebtService.GrossDebtService, 24.27)
+		tests.AssertSameFloat(t, got.DebtService.TotalDebtService, 29.27)
+		tests.AssertSameMoney(t, got.DebtService.MaximumPrice, 677894)
+	})
+
+	t.Run("should count the percentage of the rent provided", func(t *testing.T) {
+		rental := q
+		rental.MonthlyRentalIncome = 1500
+		rental.RentalPercentage = 100
+		got, err := rental.Qualify()
+		tests.AssertNilError(t, err)
+		tests.AssertSameFloat(t, got.DebtService.GrossDebtService, 27.63)
+	})
+
+	t.Run("should estimate the property tax of the municipality when it is not provided", func(t *testing.T) {
+		vancouver := q
+		vancouver.PropertyTax = 0
+		vancouver.Municipality = "Vancouver"
+		got, err := vancouver.Qualify()
+		tests.AssertNilError(t, err)
+		tests.AssertSameFloat(t, got.DebtService.GrossDebtService, 29.96)
+	})
+
+	t.Run("should return an error when the rental treatment is invalid", func(t *testing.T) {
+		invalid := q
+		invalid.RentalTreatment = "ignore"
+		_, err := invalid.Qualify()
+		tests.AssertEqualErrors(t, err, ErrInvalidRentalTreatment)
+	})
+}
@@ -0,0 +1,110 @@
+package mortgage
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+)
+
+// RentVsBuy holds the properties needed to compare buying a property with the mortgage against renting and investing
+// the down payment. Rates are yearly percentages and the rent and strata fees are monthly amounts. The rates compounded
+// every year are above -100% and at most 100%.
+type RentVsBuy struct {
+	Calculator
+	Years            int     `json:"years" validate:"required,gt=0,lte=50"`
+	ClosingCosts     float64 `json:"closingCosts" validate:"gte=0"`
+	PropertyTaxRate  float64 `json:"propertyTaxRate" validate:"gte=0"`
+	MaintenanceRate  float64 `json:"maintenanceRate" validate:"gte=0"`
+	StrataFees       float64 `json:"strataFees" validate:"gte=0"`
+	AppreciationRate float64 `json:"appreciationRate" validate:"gt=-100,lte=100"`
+	InflationRate    float64 `json:"inflationRate" validate:"gt=-100,lte=100"`
+	InvestmentReturn float64 `json:"investmentReturn" validate:"gt=-100,lte=100"`
+	Rent             float64 `json:"rent" validate:"required,gt=0"`
+	RentInflation    float64 `json:"rentInflation" validate:"gt=-100,lte=100"`
+}
+
+// RentVsBuyYear holds the costs paid and the net worth reached by the buyer and the renter in a single year.
+type RentVsBuyYear struct {
+	Year            int         `json:"year"`
+	PropertyValue   money.Money `json:"propertyValue"`
+	MortgageBalance money.Money `json:"mortgageBalance"`
+	OwnershipCost   money.Money `json:"ownershipCost"`
+	RentCost        money.Money `json:"rentCost"`
+	BuyerNetWorth   money.Money `json:"buyerNetWorth"`
+	RenterNetWorth  money.Money `json:"renterNetWorth"`
+}
+
+// RentVsBuyAnalysis holds the yearly comparison and the first year the buyer is ahead of the renter, zero when the
+// buyer never is.
+type RentVsBuyAnalysis struct {
+	Years         []RentVsBuyYear `json:"years"`
+	BreakEvenYear int             `json:"breakEvenYear"`
+}
+
+// Analyze returns the net worth of the buyer and the renter at the end of every year. The renter starts by investing
+// the down payment and closing costs, then every year whoever pays less invests the difference at the end of the year.
+// The net worth of the buyer is the property value minus the mortgage balance plus their investments.
+func (r RentVsBuy) Analyze() (RentVsBuyAnalysis, error) {
+	err := validate.Check(r)
+	if err != nil {
+		return RentVsBuyAnalysis{}, err
+	}
+
+	schedule, err := r.AmortizationSchedule()
+	if err != nil {
+		return RentVsBuyAnalysis{}, err
+	}
+
+	frequency, err := paymentFrequency(r.Schedule)
+	if err != nil {
+		return RentVsBuyAnalysis{}, err
+	}
+
+	rounding, err := money.ParseRoundingMode(r.Rounding)
+	if err != nil {
+		return RentVsBuyAnalysis{}, err
+	}
+
+	value := money.FromFloat(r.PropertyPrice)
+	strata := money.FromFloat(r.StrataFees) * 12
+	rent := money.FromFloat(r.Rent) * 12
+	renterInvestments := money.FromFloat(r.DownPayment) + money.FromFloat(r.ClosingCosts)
+	var buyerInvestments money.Money
+
+	analysis := RentVsBuyAnalysis{Years: make([]RentVsBuyYear, r.Years)}
+	for i, year := range yearlySchedule(schedule, frequency, r.Years) {
+		ownership := year.Payment + strata + value.MulRate(r.PropertyTaxRate, 1, rounding) +
+			value.MulRate(r.MaintenanceRate, 1, rounding)
+
+		buyerInvestments += buyerInvestments.MulRate(r.InvestmentReturn, 1, rounding)
+		renterInvestments += renterInvestments.MulRate(r.InvestmentReturn, 1, rounding)
+		if ownership > rent {
+			renterInvestments += ownership - rent
+		} else {
+			buyerInvestments += rent - ownership
+		}
+		value += value.MulRate(r.AppreciationRate, 1, rounding)
+
+		analysis.Years[i] = RentVsBuyYear{
+			Year:            year.Number,
+			PropertyValue:   value,
+			MortgageBalance: year.Balance,
+			OwnershipCost:   ownership,
+			RentCost:        rent,
+			BuyerNetWorth:   value - year.Balance + buyerInvestments,
+			RenterNetWorth:  renterInvestments,
+		}
+		if analysis.BreakEvenYear == 0 && analysis.Years[i].BuyerNetWorth >= renterInvestments {
+			analysis.BreakEvenYear = year.Number
+		}
+
+		strata += strata.MulRate(r.InflationRate, 1, rounding)
+		rent += rent.MulRate(r.RentInflation, 1, rounding)
+		err := money.Check(ownership, value, buyerInvestments, renterInvestments, analysis.Years[i].BuyerNetWorth,
+			strata, rent)
+		if err != nil {
+			return RentVsBuyAnalysis{}, err
+		}
+	}
+
+	return analysis, nil
+}
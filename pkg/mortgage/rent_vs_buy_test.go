@@ -0,0 +1,124 @@
+package mortgage
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"testing"
+)
+
+func TestRentVsBuy(t *testing.T) {
+	c := Calculator{
+		PropertyPrice:      500000,
+		DownPayment:        100000,
+		AnnualInterestRate: 5,
+		AmortizationPeriod: 25,
+		Schedule:           Monthly,
+	}
+	r := RentVsBuy{
+		Calculator:      c,
+		Years:           30,
+		ClosingCosts:    10000,
+		PropertyTaxRate: 0.3,
+		MaintenanceRate: 1,
+		StrataFees:      300,
+		Rent:            2000,
+	}
+	schedule, _ := c.AmortizationSchedule()
+
+	t.Run("should add up the costs of owning and renting every year", func(t *testing.T) {
+		got, err := r.Analyze()
+		tests.AssertNilError(t, err)
+		tests.AssertSameInt(t, len(got.Years), 30)
+
+		year := got.Years[0]
+		tests.AssertSameInt(t, year.Year, 1)
+		tests.AssertSameMoney(t, year.OwnershipCost, 2338.36*12+1500+5000+3600)
+		tests.AssertSameMoney(t, year.RentCost, 24000)
+		tests.AssertSameMoney(t, year.MortgageBalance, schedule[11].Balance.Float64())
+	})
+
+	t.Run("should invest the down payment and the savings of the renter", func(t *testing.T) {
+		got, err := r.Analyze()
+		tests.AssertNilError(t, err)
+
+		year := got.Years[0]
+		tests.AssertSameMoney(t, year.RenterNetWorth, 110000+38160.32-24000)
+		tests.AssertSameMoney(t, year.BuyerNetWorth, 500000-schedule[11].Balance.Float64())
+	})
+
+	t.Run("should invest the savings of the buyer once the mortgage is paid off", func(t *testing.T) {
+		got, err := r.Analyze()
+		tests.AssertNilError(t, err)
+
+		year := got.Years[25]
+		tests.AssertSameMoney(t, year.MortgageBalance, 0)
+		tests.AssertSameMoney(t, year.OwnershipCost, 10100)
+		tests.AssertSameMoney(t, year.BuyerNetWorth, 500000+24000-10100)
+	})
+
+	t.Run("should grow the property value, rent and investments", func(t *testing.T) {
+		growing := r
+		growing.AppreciationRate = 3
+		growing.InflationRate = 2
+		growing.RentInflation = 2.5
+		growing.InvestmentReturn = 6
+		got, err := growing.Analyze()
+		tests.AssertNilError(t, err)
+
+		tests.AssertSameMoney(t, got.Years[0].PropertyValue, 515000)
+		tests.AssertSameMoney(t, got.Years[1].RentCost, 24600)
+		tests.AssertSameMoney(t, got.Years[1].OwnershipCost, 2338.36*12+1545+5150+3672)
+		tests.AssertSameMoney(t, got.Years[0].RenterNetWorth, 116600+38160.32-24000)
+	})
+
+	t.Run("should return the first year the buyer is ahead", func(t *testing.T) {
+		got, err := r.Analyze()
+		tests.AssertNilError(t, err)
+
+		year := got.BreakEvenYear
+		if year == 0 {
+			t.Fatalf("got no break even year")
+		}
+		if got.Years[year-1].BuyerNetWorth < got.Years[year-1].RenterNetWorth {
+			t.Errorf("got buyer behind on the break even year %d", year)
+		}
+		if year > 1 && got.Years[year-2].BuyerNetWorth >= got.Years[year-2].RenterNetWorth {
+			t.Errorf("got buyer ahead before the break even year %d", year)
+		}
+	})
+
+	t.Run("should return no break even year when the buyer is never ahead", func(t *testing.T) {
+		cheap := r
+		cheap.Years = 5
+		cheap.Rent = 500
+		got, err := cheap.Analyze()
+		tests.AssertNilError(t, err)
+		tests.AssertSameInt(t, got.BreakEvenYear, 0)
+	})
+
+	t.Run("should return a validation error for rates out of range", func(t *testing.T) {
+		for field, set := range map[string]func(r *RentVsBuy){
+			"appreciationRate": func(r *RentVsBuy) { r.AppreciationRate = -100 },
+			"inflationRate":    func(r *RentVsBuy) { r.InflationRate = 101 },
+			"investmentReturn": func(r *RentVsBuy) { r.InvestmentReturn = -300 },
+			"rentInflation":    func(r *RentVsBuy) { r.RentInflation = 200 },
+		} {
+			invalid := r
+			set(&invalid)
+			_, err := invalid.Analyze()
+			fieldErrors := validate.GetFieldErrors(err)
+			if len(fieldErrors) == 0 || fieldErrors[0].Field != field {
+				t.Errorf("expected %s got %v", field, err)
+			}
+		}
+	})
+
+	t.Run("should return a error when the amounts exceed the supported range", func(t *testing.T) {
+		growing := r
+		growing.Years = 50
+		growing.AppreciationRate = 100
+		_, err := growing.Analyze()
+		tests.AssertEqualErrors(t, err, money.ErrAmountOutOfRange)
+	})
+}
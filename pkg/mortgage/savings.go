@@ -0,0 +1,128 @@
+package mortgage
+
+import (
+	"errors"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/province"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"math"
+	"time"
+)
+
+// maximumSavingsMonths is the longest a savings plan is projected for.
+const maximumSavingsMonths = 50 * 12
+
+// errors for savings plans
+var (
+	ErrSavingsGoalUnreachable = errors.New("savings do not reach the down payment and closing costs within 50 years")
+)
+
+// SavingsPlan holds the properties needed to find when the savings are enough to buy a property. The savings grow with
+// the monthly contributions and the yearly expected return while the target price grows at the yearly price growth,
+// both rates above -100% and at most 100%.
+// Other closing costs, such as legal fees, are added to the transfer taxes of the province, BC when not provided.
+type SavingsPlan struct {
+	TargetPrice         float64 `json:"targetPrice" validate:"required,gt=0"`
+	Savings             float64 `json:"savings" validate:"gte=0"`
+	MonthlyContribution float64 `json:"monthlyContribution" validate:"gte=0"`
+	ExpectedReturn      float64 `json:"expectedReturn" validate:"gt=-100,lte=100"`
+	PriceGrowth         float64 `json:"priceGrowth" validate:"gt=-100,lte=100"`
+	OtherClosingCosts   float64 `json:"otherClosingCosts" validate:"gte=0"`
+	StartDate           string  `json:"startDate" validate:"required,datetime=2006-01-02"`
+	Province            string  `json:"province,omitempty"`
+	Municipality        string  `json:"municipality,omitempty"`
+	FirstTimeBuyer      bool    `json:"firstTimeBuyer"`
+	Rounding            string  `json:"rounding,omitempty"`
+}
+
+// SavingsGoal holds the month the savings are enough to pay the minimum down payment and the closing costs of the
+// property at its price on that month.
+type SavingsGoal struct {
+	Months             int                    `json:"months"`
+	Date               string                 `json:"date"`
+	Price              money.Money            `json:"price"`
+	MinimumDownPayment money.Money            `json:"minimumDownPayment"`
+	TransferTaxes      province.TransferTaxes `json:"transferTaxes"`
+	CMHCPremiumTax     money.Money            `json:"cmhcPremiumTax"`
+	ClosingCosts       money.Money            `json:"closingCosts"`
+	Needed             money.Money            `json:"needed"`
+	Savings            money.Money            `json:"savings"`
+}
+
+// Goal returns the first month the savings, including the contribution and return of that month, cover the minimum
+// down payment and closing costs of the property.
+func (s SavingsPlan) Goal() (SavingsGoal, error) {
+	err := validate.Check(s)
+	if err != nil {
+		return SavingsGoal{}, err
+	}
+
+	start, err := time.Parse(dateLayout, s.StartDate)
+	if err != nil {
+		return SavingsGoal{}, err
+	}
+
+	rules, err := province.Lookup(s.Province)
+	if err != nil {
+		return SavingsGoal{}, err
+	}
+
+	rounding, err := money.ParseRoundingMode(s.Rounding)
+	if err != nil {
+		return SavingsGoal{}, err
+	}
+
+	savings := money.FromFloat(s.Savings)
+	contribution := money.FromFloat(s.MonthlyContribution)
+	for month := 0; month <= maximumSavingsMonths; month++ {
+		if month > 0 {
+			savings += savings.MulRate(s.ExpectedReturn, 12, rounding) + contribution
+		}
+
+		growth := math.Pow(1+s.PriceGrowth/100, float64(month)/12)
+		price := money.Round(s.TargetPrice*growth, rounding)
+		if err := money.Check(savings, price); err != nil {
+			return SavingsGoal{}, err
+		}
+		goal, err := s.goal(price, rules, rounding)
+		if err != nil {
+			return SavingsGoal{}, err
+		}
+		if savings >= goal.Needed {
+			goal.Months = month
+			goal.Date = paymentDate(start, Monthly, month).Format(dateLayout)
+			goal.Savings = savings
+			return goal, nil
+		}
+	}
+
+	return SavingsGoal{}, ErrSavingsGoalUnreachable
+}
+
+// goal returns the amounts needed to buy a property at the price with the minimum down payment, including the sales
+// tax on the CMHC premium of the provinces charging it.
+func (s SavingsPlan) goal(price money.Money, rules province.Rules, rounding money.RoundingMode) (SavingsGoal, error) {
+	downPayment := minimumDownPayment(price)
+	c := Calculator{
+		PropertyPrice: price.Float64(),
+		DownPayment:   downPayment.Float64(),
+		Province:      s.Province,
+		Rounding:      s.Rounding,
+	}
+	premiumTax, err := c.calculateCMHCPremiumTax()
+	if err != nil {
+		return SavingsGoal{}, err
+	}
+
+	transferTaxes := rules.TransferTaxes(price, s.Municipality, s.FirstTimeBuyer, rounding)
+	closingCosts := transferTaxes.Total + premiumTax + money.FromFloat(s.OtherClosingCosts)
+
+	return SavingsGoal{
+		Price:              price,
+		MinimumDownPayment: downPayment,
+		TransferTaxes:      transferTaxes,
+		CMHCPremiumTax:     premiumTax,
+		ClosingCosts:       closingCosts,
+		Needed:             downPayment + closingCosts,
+	}, nil
+}
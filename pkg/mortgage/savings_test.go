@@ -0,0 +1,98 @@
+package mortgage
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/province"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"testing"
+)
+
+func TestSavingsPlanGoal(t *testing.T) {
+	s := SavingsPlan{
+		TargetPrice:         500000,
+		Savings:             10000,
+		MonthlyContribution: 1000,
+		OtherClosingCosts:   2000,
+		StartDate:           "2024-01-15",
+	}
+
+	t.Run("should return the month the savings cover the down payment and closing costs", func(t *testing.T) {
+		got, err := s.Goal()
+		tests.AssertNilError(t, err)
+
+		tests.AssertSameMoney(t, got.MinimumDownPayment, 25000)
+		tests.AssertSameMoney(t, got.TransferTaxes.Total, 8000)
+		tests.AssertSameMoney(t, got.ClosingCosts, 10000)
+		tests.AssertSameMoney(t, got.Needed, 35000)
+		tests.AssertSameInt(t, got.Months, 25)
+		tests.AssertSameMoney(t, got.Savings, 35000)
+		if got.Date != "2026-02-15" {
+			t.Errorf("got %s want 2026-02-15", got.Date)
+		}
+	})
+
+	t.Run("should return the current month when the savings are already enough", func(t *testing.T) {
+		rich := s
+		rich.Savings = 40000
+		got, err := rich.Goal()
+		tests.AssertNilError(t, err)
+		tests.AssertSameInt(t, got.Months, 0)
+		if got.Date != "2024-01-15" {
+			t.Errorf("got %s want 2024-01-15", got.Date)
+		}
+	})
+
+	t.Run("should grow the savings and the price", func(t *testing.T) {
+		growing := s
+		growing.ExpectedReturn = 6
+		growing.PriceGrowth = 5
+		got, err := growing.Goal()
+		tests.AssertNilError(t, err)
+
+		if got.Price <= 500000_00 || got.Savings < got.Needed {
+			t.Errorf("got price %v and savings %v for %v needed", got.Price, got.Savings, got.Needed)
+		}
+		if got.Months == 25 {
+			t.Errorf("got the same month as without growth")
+		}
+	})
+
+	t.Run("should use the transfer taxes of the province", func(t *testing.T) {
+		toronto := s
+		toronto.Province = province.Ontario
+		toronto.Municipality = "Toronto"
+		toronto.FirstTimeBuyer = true
+		got, err := toronto.Goal()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.TransferTaxes.FirstTimeBuyerRebate, 8475)
+		tests.AssertSameMoney(t, got.CMHCPremiumTax, 1520)
+		tests.AssertSameMoney(t, got.ClosingCosts, 12950-8475+1520+2000)
+
+		toronto.Province = "XX"
+		_, err = toronto.Goal()
+		tests.AssertEqualErrors(t, err, province.ErrUnsupportedProvince)
+	})
+
+	t.Run("should return an error when the savings never reach the goal", func(t *testing.T) {
+		slow := s
+		slow.MonthlyContribution = 0
+		slow.PriceGrowth = 3
+		_, err := slow.Goal()
+		tests.AssertEqualErrors(t, err, ErrSavingsGoalUnreachable)
+	})
+
+	t.Run("should return a validation error for rates out of range", func(t *testing.T) {
+		for field, set := range map[string]func(s *SavingsPlan){
+			"priceGrowth":    func(s *SavingsPlan) { s.PriceGrowth = -100 },
+			"expectedReturn": func(s *SavingsPlan) { s.ExpectedReturn = 150 },
+		} {
+			invalid := s
+			set(&invalid)
+			_, err := invalid.Goal()
+			fieldErrors := validate.GetFieldErrors(err)
+			if len(fieldErrors) == 0 || fieldErrors[0].Field != field {
+				t.Errorf("expected %s got %v", field, err)
+			}
+		}
+	})
+}
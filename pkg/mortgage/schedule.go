@@ -0,0 +1,138 @@
+package mortgage
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"strings"
+	"time"
+)
+
+// dateLayout is the format of the dates received and returned, the time of the day is not relevant to payments.
+const dateLayout = "2006-01-02"
+
+// Period holds the breakdown of a single payment of an amortization schedule.
+type Period struct {
+	Number    int         `json:"number"`
+	Payment   money.Money `json:"payment"`
+	Interest  money.Money `json:"interest"`
+	Principal money.Money `json:"principal"`
+	Balance   money.Money `json:"balance"`
+}
+
+// amortize builds the amortization schedule of a loan paid frequency times a year, the interest of every period is
+// rounded to the cent and the last payment is adjusted so the principal paid adds up to the loan.
+func amortize(principal money.Money, annualInterestRate float64, frequency int, payment money.Money,
+	numberOfPayments int, rounding money.RoundingMode) []Period {
+	return prepay(principal, annualInterestRate, frequency, payment, 0, 0, numberOfPayments, rounding)
+}
+
+// amortizeTerm returns the interest paid over the first periods of the schedule amortize builds and the balance left
+// after them, without building the periods.
+func amortizeTerm(principal money.Money, annualInterestRate float64, frequency int, payment money.Money,
+	numberOfPayments, periods int, rounding money.RoundingMode) (money.Money, money.Money) {
+	var interestPaid money.Money
+	balance := principal
+	for n := 1; n <= periods && n <= numberOfPayments && balance > 0; n++ {
+		interest := balance.MulRate(annualInterestRate, frequency, rounding)
+		principalPaid := payment - interest
+		if principalPaid > balance || n == numberOfPayments {
+			principalPaid = balance
+		}
+		balance -= principalPaid
+		interestPaid += interest
+	}
+	return interestPaid, balance
+}
+
+// prepay builds the amortization schedule of a loan like amortize does, adding the extra amount to every payment and
+// the lump sum to the last payment of every year until the loan is paid off.
+func prepay(principal money.Money, annualInterestRate float64, frequency int, payment, extra, lumpSum money.Money,
+	numberOfPayments int, rounding money.RoundingMode) []Period {
+	schedule := make([]Period, 0, numberOfPayments)
+	balance := principal
+
+	for n := 1; n <= numberOfPayments && balance > 0; n++ {
+		interest := balance.MulRate(annualInterestRate, frequency, rounding)
+		paid := payment + extra
+		if n%frequency == 0 {
+			paid += lumpSum
+		}
+		principalPaid := paid - interest
+		if principalPaid > balance || n == numberOfPayments {
+			principalPaid = balance
+			paid = balance + interest
+		}
+		balance -= principalPaid
+
+		schedule = append(schedule, Period{
+			Number:    n,
+			Payment:   paid,
+			Interest:  interest,
+			Principal: principalPaid,
+			Balance:   balance,
+		})
+	}
+
+	return schedule
+}
+
+// combineSchedules adds up several schedules paid on the same dates period by period.
+func combineSchedules(schedules ...[]Period) []Period {
+	var combined []Period
+	for _, schedule := range schedules {
+		for i, period := range schedule {
+			if i == len(combined) {
+				combined = append(combined, Period{Number: period.Number})
+			}
+			combined[i].Payment += period.Payment
+			combined[i].Interest += period.Interest
+			combined[i].Principal += period.Principal
+			combined[i].Balance += period.Balance
+		}
+	}
+	return combined
+}
+
+// totalInterest returns the interest paid over the periods of a schedule.
+func totalInterest(schedule []Period) money.Money {
+	var interest money.Money
+	for _, period := range schedule {
+		interest += period.Interest
+	}
+	return interest
+}
+
+// yearlySchedule adds up the periods of a schedule paid frequency times a year into the given number of years, the
+// balance of a year is the one left after its last payment and the years after the payoff are empty.
+func yearlySchedule(schedule []Period, frequency, years int) []Period {
+	yearly := make([]Period, years)
+	for i := range yearly {
+		yearly[i].Number = i + 1
+	}
+	for i, period := range schedule {
+		year := i / frequency
+		if year >= years {
+			break
+		}
+		yearly[year].Payment += period.Payment
+		yearly[year].Interest += period.Interest
+		yearly[year].Principal += period.Principal
+		yearly[year].Balance = period.Balance
+	}
+	return yearly
+}
+
+// paymentDate returns the date of the payment number n of a schedule starting on the start date. Monthly payments are
+// made on the same day of every month, or the last day of shorter months, and biweekly payments every 14 days.
+func paymentDate(start time.Time, schedule string, n int) time.Time {
+	if strings.ToUpper(schedule) != Monthly {
+		return start.AddDate(0, 0, 14*n)
+	}
+
+	month := time.Date(start.Year(), start.Month()+time.Month(n), 1, 0, 0, 0, 0, start.Location())
+	lastDay := month.AddDate(0, 1, -1).Day()
+	day := start.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(month.Year(), month.Month(), day, 0, 0, 0, 0, start.Location())
+}
@@ -0,0 +1,179 @@
+package mortgage
+
+import (
+	"fmt"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"testing"
+	"time"
+)
+
+func TestAmortize(t *testing.T) {
+	t.Run("should split every payment into interest and principal until the balance is paid", func(t *testing.T) {
+		got := amortize(money.FromFloat(1000), 12, 12, money.FromFloat(200), 6, money.HalfUp)
+
+		tests.AssertSameInt(t, len(got), 6)
+		AssertPeriod(t, got[0], newPeriod(1, 200, 10, 190, 810))
+		AssertPeriod(t, got[1], newPeriod(2, 200, 8.1, 191.9, 618.1))
+	})
+
+	t.Run("should adjust the last payment to pay off the remaining balance", func(t *testing.T) {
+		got := amortize(money.FromFloat(1000), 12, 12, money.FromFloat(200), 6, money.HalfUp)
+
+		AssertPeriod(t, got[5], newPeriod(6, 31.12, 0.31, 30.81, 0))
+	})
+
+	t.Run("should pay off the balance on the last payment when the payment is not large enough", func(t *testing.T) {
+		got := amortize(money.FromFloat(1000), 12, 12, money.FromFloat(100), 2, money.HalfUp)
+
+		AssertPeriod(t, got[1], newPeriod(2, 919.1, 9.1, 910, 0))
+	})
+
+	t.Run("should stop the schedule once the balance is paid", func(t *testing.T) {
+		got := amortize(money.FromFloat(1000), 12, 12, money.FromFloat(600), 12, money.HalfUp)
+
+		tests.AssertSameInt(t, len(got), 2)
+	})
+
+	t.Run("should round the interest according to the rounding mode", func(t *testing.T) {
+		halfUp := amortize(money.FromFloat(1.5), 12, 12, money.FromFloat(1), 2, money.HalfUp)
+		halfEven := amortize(money.FromFloat(1.5), 12, 12, money.FromFloat(1), 2, money.HalfEven)
+
+		tests.AssertSameMoney(t, halfUp[0].Interest, 0.02)
+		tests.AssertSameMoney(t, halfEven[0].Interest, 0.02)
+		tests.AssertSameMoney(t, halfUp[1].Interest, 0.01)
+		tests.AssertSameMoney(t, halfEven[1].Interest, 0.01)
+
+		halfUp = amortize(money.FromFloat(2.5), 12, 12, money.FromFloat(1), 1, money.HalfUp)
+		halfEven = amortize(money.FromFloat(2.5), 12, 12, money.FromFloat(1), 1, money.HalfEven)
+
+		tests.AssertSameMoney(t, halfUp[0].Interest, 0.03)
+		tests.AssertSameMoney(t, halfEven[0].Interest, 0.02)
+	})
+
+	t.Run("should reconcile the principal paid and the balances to the cent", func(t *testing.T) {
+		principal := money.FromFloat(98800)
+		got := amortize(principal, 4.29, 26, money.FromFloat(916.26), 130, money.HalfEven)
+
+		var paid, interest, principalPaid money.Money
+		balance := principal
+		for _, period := range got {
+			paid += period.Payment
+			interest += period.Interest
+			principalPaid += period.Principal
+			balance -= period.Principal
+			if period.Balance != balance {
+				t.Fatalf("got %s want %s on period %d", period.Balance, balance, period.Number)
+			}
+		}
+
+		if principalPaid != principal {
+			t.Errorf("got %s want %s", principalPaid, principal)
+		}
+		if paid != principal+interest {
+			t.Errorf("got %s want %s", paid, principal+interest)
+		}
+		tests.AssertSameMoney(t, got[len(got)-1].Balance, 0)
+	})
+}
+
+func TestAmortizeTerm(t *testing.T) {
+	t.Run("should match the interest and balance of the first periods of the schedule", func(t *testing.T) {
+		principal := money.FromFloat(400000)
+		schedule := amortize(principal, 5, 26, money.FromFloat(1077.81), 650, money.HalfUp)
+
+		for _, periods := range []int{1, 130, 650, 1000} {
+			interest, balance := amortizeTerm(principal, 5, 26, money.FromFloat(1077.81), 650, periods, money.HalfUp)
+			term := schedule
+			if periods < len(term) {
+				term = term[:periods]
+			}
+			tests.AssertSameMoney(t, interest, totalInterest(term).Float64())
+			tests.AssertSameMoney(t, balance, term[len(term)-1].Balance.Float64())
+		}
+	})
+}
+
+func TestPrepay(t *testing.T) {
+	t.Run("should add the extra to every payment and the lump sum to the last payment of the year", func(t *testing.T) {
+		got := prepay(money.FromFloat(1000), 12, 2, money.FromFloat(100), money.FromFloat(50), money.FromFloat(200), 10,
+			money.HalfUp)
+
+		AssertPeriod(t, got[0], newPeriod(1, 150, 60, 90, 910))
+		AssertPeriod(t, got[1], newPeriod(2, 350, 54.6, 295.4, 614.6))
+	})
+}
+
+func TestCombineSchedules(t *testing.T) {
+	t.Run("should add up the periods of every schedule", func(t *testing.T) {
+		first := []Period{newPeriod(1, 100, 10, 90, 910)}
+		second := []Period{newPeriod(1, 50, 5, 45, 455), newPeriod(2, 50, 4.55, 45.45, 409.55)}
+
+		got := combineSchedules(first, second)
+
+		tests.AssertSameInt(t, len(got), 2)
+		AssertPeriod(t, got[0], newPeriod(1, 150, 15, 135, 1365))
+		AssertPeriod(t, got[1], second[1])
+	})
+}
+
+func TestYearlySchedule(t *testing.T) {
+	t.Run("should add up the periods of every year", func(t *testing.T) {
+		schedule := amortize(money.FromFloat(1000), 12, 2, money.FromFloat(200), 6, money.HalfUp)
+
+		got := yearlySchedule(schedule, 2, 4)
+
+		tests.AssertSameInt(t, len(got), 4)
+		AssertPeriod(t, got[0], Period{
+			Number:    1,
+			Payment:   schedule[0].Payment + schedule[1].Payment,
+			Interest:  schedule[0].Interest + schedule[1].Interest,
+			Principal: schedule[0].Principal + schedule[1].Principal,
+			Balance:   schedule[1].Balance,
+		})
+		tests.AssertSameMoney(t, got[2].Balance, 0)
+		AssertPeriod(t, got[3], Period{Number: 4})
+	})
+}
+
+func TestPaymentDate(t *testing.T) {
+	start := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		schedule string
+		n        int
+		want     string
+	}{
+		{Monthly, 0, "2024-01-31"},
+		{Monthly, 1, "2024-02-29"},
+		{Monthly, 2, "2024-03-31"},
+		{Monthly, 13, "2025-02-28"},
+		{Biweekly, 1, "2024-02-14"},
+		{AcceleratedBiweekly, 26, "2025-01-29"},
+	}
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("should return the date of payment %d of a %s schedule", tc.n, tc.schedule), func(t *testing.T) {
+			got := paymentDate(start, tc.schedule, tc.n).Format(dateLayout)
+			if got != tc.want {
+				t.Errorf("got %s want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func newPeriod(number int, payment, interest, principal, balance float64) Period {
+	return Period{
+		Number:    number,
+		Payment:   money.FromFloat(payment),
+		Interest:  money.FromFloat(interest),
+		Principal: money.FromFloat(principal),
+		Balance:   money.FromFloat(balance),
+	}
+}
+
+func AssertPeriod(t testing.TB, got, want Period) {
+	t.Helper()
+	if got != want {
+		t.Errorf("got %+v want %+v", got, want)
+	}
+}
@@ -0,0 +1,161 @@
+package mortgage
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"math"
+)
+
+// maximumGridCells is the largest number of calculations a sensitivity grid may require.
+const maximumGridCells = 500
+
+// errors for sensitivity grids
+var (
+	ErrInvalidAxisField    = errors.New("axis field must be propertyPrice, downPayment, annualInterestRate, amortizationPeriod or term")
+	ErrInvalidAxisRange    = errors.New("axis must go from a value up to a greater or equal one")
+	ErrAxisNotWholeNumbers = errors.New("axis values of amortizationPeriod and term must be whole numbers")
+	ErrDuplicatedAxisField = errors.New("axes must vary different fields")
+	ErrGridTooLarge        = fmt.Errorf("grid must not have more than %d cells", maximumGridCells)
+)
+
+// axisFields sets the value of every calculator field an axis can vary.
+var axisFields = map[string]func(c *Calculator, value float64){
+	"propertyPrice":      func(c *Calculator, value float64) { c.PropertyPrice = value },
+	"downPayment":        func(c *Calculator, value float64) { c.DownPayment = value },
+	"annualInterestRate": func(c *Calculator, value float64) { c.AnnualInterestRate = value },
+	"amortizationPeriod": func(c *Calculator, value float64) { c.AmortizationPeriod = int(value) },
+	"term":               func(c *Calculator, value float64) { c.Term = int(value) },
+}
+
+// Axis holds the values a calculator field takes across a sensitivity grid, from one value up to another in steps.
+type Axis struct {
+	Field string  `json:"field" validate:"required"`
+	From  float64 `json:"from" validate:"gte=0"`
+	To    float64 `json:"to" validate:"gte=0"`
+	Step  float64 `json:"step" validate:"required,gt=0"`
+}
+
+// Sensitivity holds a base calculator and the axes along which its fields vary, the first axis makes the rows of the
+// grid and the optional second one its columns.
+type Sensitivity struct {
+	Base Calculator `json:"base"`
+	Axes []Axis     `json:"axes" validate:"required,min=1,max=2,dive"`
+}
+
+// AxisValues holds the values taken by the field of an axis.
+type AxisValues struct {
+	Field  string    `json:"field"`
+	Values []float64 `json:"values"`
+}
+
+// SensitivityCell holds the outputs of the base calculator with the values of a row and a column.
+type SensitivityCell struct {
+	PaymentPerSchedule money.Money `json:"paymentPerSchedule"`
+	TotalInterest      money.Money `json:"totalInterest"`
+}
+
+// SensitivityGrid holds the outputs of every combination of the values of the axes.
+type SensitivityGrid struct {
+	Rows    AxisValues          `json:"rows"`
+	Columns *AxisValues         `json:"columns,omitempty"`
+	Cells   [][]SensitivityCell `json:"cells"`
+}
+
+// Grid returns the payment and total interest of the base calculator for every combination of the values of the axes,
+// a grid with a single axis has one cell per row.
+func (s Sensitivity) Grid() (SensitivityGrid, error) {
+	err := validate.Check(s)
+	if err != nil {
+		return SensitivityGrid{}, err
+	}
+
+	if len(s.Axes) == 2 && s.Axes[0].Field == s.Axes[1].Field {
+		return SensitivityGrid{}, ErrDuplicatedAxisField
+	}
+
+	cells := 1
+	for _, axis := range s.Axes {
+		n, err := axis.size()
+		if err != nil {
+			return SensitivityGrid{}, err
+		}
+		cells *= n
+		if cells > maximumGridCells {
+			return SensitivityGrid{}, ErrGridTooLarge
+		}
+	}
+
+	grid := SensitivityGrid{Rows: s.Axes[0].values()}
+	columns := AxisValues{Values: []float64{0}}
+	if len(s.Axes) == 2 {
+		columns = s.Axes[1].values()
+		grid.Columns = &columns
+	}
+
+	grid.Cells = make([][]SensitivityCell, len(grid.Rows.Values))
+	for i, row := range grid.Rows.Values {
+		grid.Cells[i] = make([]SensitivityCell, len(columns.Values))
+		for j, column := range columns.Values {
+			c := s.Base
+			axisFields[grid.Rows.Field](&c, row)
+			if grid.Columns != nil {
+				axisFields[columns.Field](&c, column)
+			}
+
+			summary, err := c.Summary()
+			if err != nil {
+				return SensitivityGrid{}, fmt.Errorf("%s: %w", cellName(grid, i, j), err)
+			}
+			grid.Cells[i][j] = SensitivityCell{
+				PaymentPerSchedule: summary.PaymentPerSchedule,
+				TotalInterest:      summary.TotalInterest,
+			}
+		}
+	}
+
+	return grid, nil
+}
+
+// size returns the number of values taken by the field of the axis.
+func (a Axis) size() (int, error) {
+	if _, ok := axisFields[a.Field]; !ok {
+		return 0, ErrInvalidAxisField
+	}
+
+	if a.To < a.From {
+		return 0, ErrInvalidAxisRange
+	}
+
+	wholeNumbers := a.From == math.Trunc(a.From) && a.Step == math.Trunc(a.Step)
+	if (a.Field == "amortizationPeriod" || a.Field == "term") && !wholeNumbers {
+		return 0, ErrAxisNotWholeNumbers
+	}
+
+	// Tolerate the floating point error of steps such as 0.1 so the last value is not lost.
+	steps := math.Floor((a.To-a.From)/a.Step + 1e-9)
+	if steps >= maximumGridCells {
+		return 0, ErrGridTooLarge
+	}
+	return int(steps) + 1, nil
+}
+
+// values returns the values taken by the field of the axis, rounded to remove the floating point error of the steps.
+func (a Axis) values() AxisValues {
+	n, _ := a.size()
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = math.Round((a.From+float64(i)*a.Step)*1_000_000) / 1_000_000
+	}
+	return AxisValues{Field: a.Field, Values: values}
+}
+
+// cellName identifies a cell of the grid by the values of its fields.
+func cellName(grid SensitivityGrid, row, column int) string {
+	name := fmt.Sprintf("%s %v", grid.Rows.Field, grid.Rows.Values[row])
+	if grid.Columns != nil {
+		name += fmt.Sprintf(", %s %v", grid.Columns.Field, grid.Columns.Values[column])
+	}
+	return name
+}
@@ -0,0 +1,92 @@
+package mortgage
+
+import (
+	"errors"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"testing"
+)
+
+func TestSensitivityGrid(t *testing.T) {
+	base := Calculator{
+		PropertyPrice:      500000,
+		DownPayment:        100000,
+		AnnualInterestRate: 5,
+		AmortizationPeriod: 25,
+		Schedule:           Monthly,
+	}
+
+	t.Run("should return one cell per value of a single axis", func(t *testing.T) {
+		s := Sensitivity{Base: base, Axes: []Axis{{Field: "annualInterestRate", From: 3, To: 7, Step: 0.25}}}
+		got, err := s.Grid()
+		tests.AssertNilError(t, err)
+		tests.AssertSameInt(t, len(got.Rows.Values), 17)
+		tests.AssertSameFloat(t, got.Rows.Values[1], 3.25)
+		tests.AssertSameFloat(t, got.Rows.Values[16], 7)
+		if got.Columns != nil {
+			t.Errorf("got columns %v, want none", got.Columns)
+		}
+		tests.AssertSameInt(t, len(got.Cells[8]), 1)
+		tests.AssertSameMoney(t, got.Cells[8][0].PaymentPerSchedule, 2338.36)
+		tests.AssertSameMoney(t, got.Cells[8][0].TotalInterest, 301508.31)
+	})
+
+	t.Run("should return the outputs of every combination of two axes", func(t *testing.T) {
+		s := Sensitivity{Base: base, Axes: []Axis{
+			{Field: "annualInterestRate", From: 4.5, To: 5, Step: 0.5},
+			{Field: "amortizationPeriod", From: 20, To: 30, Step: 5},
+		}}
+		got, err := s.Grid()
+		tests.AssertNilError(t, err)
+		tests.AssertSameInt(t, len(got.Cells), 2)
+		tests.AssertSameInt(t, len(got.Cells[0]), 3)
+
+		c := base
+		c.AnnualInterestRate = 4.5
+		c.AmortizationPeriod = 30
+		want, _ := c.Summary()
+		tests.AssertSameMoney(t, got.Cells[0][2].PaymentPerSchedule, want.PaymentPerSchedule.Float64())
+		tests.AssertSameMoney(t, got.Cells[1][1].PaymentPerSchedule, 2338.36)
+	})
+
+	t.Run("should return an error for grids larger than the limit", func(t *testing.T) {
+		s := Sensitivity{Base: base, Axes: []Axis{
+			{Field: "annualInterestRate", From: 1, To: 10, Step: 0.25},
+			{Field: "propertyPrice", From: 400000, To: 800000, Step: 10000},
+		}}
+		_, err := s.Grid()
+		tests.AssertEqualErrors(t, err, ErrGridTooLarge)
+
+		s = Sensitivity{Base: base, Axes: []Axis{{Field: "annualInterestRate", From: 1, To: 10, Step: 0.0001}}}
+		_, err = s.Grid()
+		tests.AssertEqualErrors(t, err, ErrGridTooLarge)
+	})
+
+	t.Run("should return an error for invalid axes", func(t *testing.T) {
+		cases := []struct {
+			axes []Axis
+			want error
+		}{
+			{[]Axis{{Field: "schedule", From: 1, To: 2, Step: 1}}, ErrInvalidAxisField},
+			{[]Axis{{Field: "annualInterestRate", From: 5, To: 4, Step: 1}}, ErrInvalidAxisRange},
+			{[]Axis{{Field: "term", From: 1, To: 5, Step: 0.5}}, ErrAxisNotWholeNumbers},
+			{[]Axis{{Field: "term", From: 1, To: 5, Step: 1}, {Field: "term", From: 1, To: 5, Step: 1}},
+				ErrDuplicatedAxisField},
+		}
+		for _, tc := range cases {
+			_, err := Sensitivity{Base: base, Axes: tc.axes}.Grid()
+			tests.AssertEqualErrors(t, err, tc.want)
+		}
+	})
+
+	t.Run("should return the error of the cell that can not be calculated", func(t *testing.T) {
+		s := Sensitivity{Base: base, Axes: []Axis{{Field: "amortizationPeriod", From: 20, To: 35, Step: 5}}}
+		_, err := s.Grid()
+		if !errors.Is(err, ErrPeriodOutOfRange) {
+			t.Fatalf("got %v, want %v", err, ErrPeriodOutOfRange)
+		}
+		want := "amortizationPeriod 35: " + ErrPeriodOutOfRange.Error()
+		if err.Error() != want {
+			t.Errorf("got %s, want %s", err, want)
+		}
+	})
+}
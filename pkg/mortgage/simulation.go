@@ -0,0 +1,196 @@
+package mortgage
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// RateModel holds the parameters of a mean reverting (Vasicek) model of the renewal rates, the rate is pulled towards
+// its long term value at the reversion speed per year while moving randomly with the volatility, in percentage points
+// per square root of a year, up to 10.
+type RateModel struct {
+	LongTermRate float64 `json:"longTermRate" validate:"required,gt=0"`
+	Reversion    float64 `json:"reversion" validate:"gte=0"`
+	Volatility   float64 `json:"volatility" validate:"gte=0,lte=10"`
+	MinimumRate  float64 `json:"minimumRate" validate:"gte=0"`
+}
+
+// Simulation holds the mortgage whose renewal rates are drawn from the rate model on every path, the same seed
+// always draws the same rates.
+type Simulation struct {
+	Calculator
+	Model RateModel `json:"model"`
+	Paths int       `json:"paths" validate:"required,gt=0,lte=10000"`
+	Seed  int64     `json:"seed"`
+}
+
+// RatePercentiles holds the percentiles of a rate across the simulated paths.
+type RatePercentiles struct {
+	P5  float64 `json:"p5"`
+	P25 float64 `json:"p25"`
+	P50 float64 `json:"p50"`
+	P75 float64 `json:"p75"`
+	P95 float64 `json:"p95"`
+}
+
+// MoneyPercentiles holds the percentiles of an amount across the simulated paths.
+type MoneyPercentiles struct {
+	P5  money.Money `json:"p5"`
+	P25 money.Money `json:"p25"`
+	P50 money.Money `json:"p50"`
+	P75 money.Money `json:"p75"`
+	P95 money.Money `json:"p95"`
+}
+
+// RenewalOutcome holds the distribution of the rate and payment agreed at a renewal, the first renewal is the original
+// mortgage.
+type RenewalOutcome struct {
+	Renewal int              `json:"renewal"`
+	Year    int              `json:"year"`
+	Rate    RatePercentiles  `json:"rate"`
+	Payment MoneyPercentiles `json:"payment"`
+}
+
+// SimulationResult holds the distribution of the payments at every renewal and of the interest paid.
+type SimulationResult struct {
+	Renewals      []RenewalOutcome `json:"renewals"`
+	TotalInterest MoneyPercentiles `json:"totalInterest"`
+}
+
+// Simulate runs the mortgage through its renewals on every path, the balance left at the end of every term is
+// amortized over the remaining years at a rate drawn from the model.
+func (s Simulation) Simulate() (SimulationResult, error) {
+	err := validate.Check(s)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+
+	// Validate the mortgage before simulating any path.
+	if _, err := s.PaymentSchedule(); err != nil {
+		return SimulationResult{}, err
+	}
+
+	term, err := s.term()
+	if err != nil {
+		return SimulationResult{}, err
+	}
+
+	frequency, err := paymentFrequency(s.Schedule)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+
+	rounding, err := money.ParseRoundingMode(s.Rounding)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+
+	principal, err := s.calculateTotalMortgage()
+	if err != nil {
+		return SimulationResult{}, err
+	}
+
+	renewals := (s.AmortizationPeriod + term - 1) / term
+	rates := make([][]float64, renewals)
+	payments := make([][]money.Money, renewals)
+	interest := make([]money.Money, s.Paths)
+
+	random := rand.New(rand.NewSource(s.Seed))
+	for path := 0; path < s.Paths; path++ {
+		balance := principal
+		rate := s.AnnualInterestRate
+		for renewal := 0; renewal < renewals; renewal++ {
+			remaining := s.AmortizationPeriod - renewal*term
+			years := term
+			if remaining < years {
+				years = remaining
+			}
+
+			var payment money.Money
+			if balance > 0 {
+				payment, err = schedulePayment(balance, rate, remaining, s.Schedule, rounding)
+				if err != nil {
+					return SimulationResult{}, err
+				}
+
+				var paid money.Money
+				paid, balance = amortizeTerm(balance, rate, frequency, payment, frequency*remaining, frequency*years,
+					rounding)
+				interest[path] += paid
+			}
+
+			rates[renewal] = append(rates[renewal], rate)
+			payments[renewal] = append(payments[renewal], payment)
+			rate = s.Model.next(rate, float64(years), random)
+		}
+	}
+
+	result := SimulationResult{
+		Renewals:      make([]RenewalOutcome, renewals),
+		TotalInterest: moneyPercentiles(interest),
+	}
+	for renewal := range result.Renewals {
+		result.Renewals[renewal] = RenewalOutcome{
+			Renewal: renewal,
+			Year:    renewal * term,
+			Rate:    ratePercentiles(rates[renewal]),
+			Payment: moneyPercentiles(payments[renewal]),
+		}
+	}
+
+	return result, nil
+}
+
+// next draws the rate reached after the given years from the current rate, using the exact transition of the model so
+// the result does not depend on the length of the term. The rate never falls under the minimum.
+func (m RateModel) next(rate, years float64, random *rand.Rand) float64 {
+	mean := rate
+	deviation := m.Volatility * math.Sqrt(years)
+	if m.Reversion > 0 {
+		decay := math.Exp(-m.Reversion * years)
+		mean = m.LongTermRate + (rate-m.LongTermRate)*decay
+		deviation = m.Volatility * math.Sqrt((1-decay*decay)/(2*m.Reversion))
+	}
+
+	next := mean + deviation*random.NormFloat64()
+	if next < m.MinimumRate {
+		next = m.MinimumRate
+	}
+	return next
+}
+
+// ratePercentiles returns the percentiles of the rates rounded to 2 decimals.
+func ratePercentiles(rates []float64) RatePercentiles {
+	sorted := make([]float64, len(rates))
+	copy(sorted, rates)
+	sort.Float64s(sorted)
+
+	at := func(p float64) float64 {
+		return math.Round(sorted[percentileIndex(p, len(sorted))]*100) / 100
+	}
+	return RatePercentiles{P5: at(5), P25: at(25), P50: at(50), P75: at(75), P95: at(95)}
+}
+
+// moneyPercentiles returns the percentiles of the amounts.
+func moneyPercentiles(amounts []money.Money) MoneyPercentiles {
+	sorted := make([]money.Money, len(amounts))
+	copy(sorted, amounts)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	at := func(p float64) money.Money {
+		return sorted[percentileIndex(p, len(sorted))]
+	}
+	return MoneyPercentiles{P5: at(5), P25: at(25), P50: at(50), P75: at(75), P95: at(95)}
+}
+
+// percentileIndex returns the index of the percentile in n sorted values using the nearest rank method.
+func percentileIndex(p float64, n int) int {
+	i := int(math.Ceil(p/100*float64(n))) - 1
+	if i < 0 {
+		return 0
+	}
+	return i
+}
@@ -0,0 +1,127 @@
+package mortgage
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSimulate(t *testing.T) {
+	c := Calculator{
+		PropertyPrice:      500000,
+		DownPayment:        100000,
+		AnnualInterestRate: 5,
+		AmortizationPeriod: 25,
+		Schedule:           Monthly,
+	}
+	model := RateModel{LongTermRate: 4, Reversion: 0.3, Volatility: 1, MinimumRate: 0.5}
+
+	t.Run("should return one outcome per renewal", func(t *testing.T) {
+		s := Simulation{Calculator: c, Model: model, Paths: 200, Seed: 1}
+		got, err := s.Simulate()
+		tests.AssertNilError(t, err)
+		tests.AssertSameInt(t, len(got.Renewals), 5)
+		for i, renewal := range got.Renewals {
+			tests.AssertSameInt(t, renewal.Renewal, i)
+			tests.AssertSameInt(t, renewal.Year, i*5)
+		}
+		tests.AssertSameFloat(t, got.Renewals[0].Rate.P5, 5)
+		tests.AssertSameMoney(t, got.Renewals[0].Payment.P95, 2338.36)
+	})
+
+	t.Run("should return the same result for the same seed", func(t *testing.T) {
+		s := Simulation{Calculator: c, Model: model, Paths: 200, Seed: 42}
+		first, err := s.Simulate()
+		tests.AssertNilError(t, err)
+		second, err := s.Simulate()
+		tests.AssertNilError(t, err)
+		if !reflect.DeepEqual(first, second) {
+			t.Errorf("got %v and %v, want the same result", first, second)
+		}
+
+		s.Seed = 43
+		third, err := s.Simulate()
+		tests.AssertNilError(t, err)
+		if reflect.DeepEqual(first, third) {
+			t.Errorf("got the same result for different seeds")
+		}
+	})
+
+	t.Run("should return ordered percentiles", func(t *testing.T) {
+		s := Simulation{Calculator: c, Model: model, Paths: 1000, Seed: 7}
+		got, err := s.Simulate()
+		tests.AssertNilError(t, err)
+		for _, renewal := range got.Renewals[1:] {
+			p := renewal.Payment
+			if !(p.P5 <= p.P25 && p.P25 <= p.P50 && p.P50 <= p.P75 && p.P75 <= p.P95) || p.P5 == p.P95 {
+				t.Errorf("got unordered percentiles %v", p)
+			}
+			if renewal.Rate.P5 < model.MinimumRate {
+				t.Errorf("got rate %v, want at least %v", renewal.Rate.P5, model.MinimumRate)
+			}
+		}
+	})
+
+	t.Run("should match the amortization schedule when the rate does not move", func(t *testing.T) {
+		s := Simulation{Calculator: c, Model: RateModel{LongTermRate: 5}, Paths: 10}
+		got, err := s.Simulate()
+		tests.AssertNilError(t, err)
+
+		summary, err := c.Summary()
+		tests.AssertNilError(t, err)
+		for _, renewal := range got.Renewals {
+			if math.Abs(renewal.Payment.P50.Float64()-2338.36) > 0.05 {
+				t.Errorf("got %v, want about 2338.36", renewal.Payment.P50)
+			}
+		}
+		if math.Abs(got.TotalInterest.P50.Float64()-summary.TotalInterest.Float64()) > 5 {
+			t.Errorf("got %v, want about %v", got.TotalInterest.P50, summary.TotalInterest)
+		}
+	})
+
+	t.Run("should pull the renewal rate towards the long term rate", func(t *testing.T) {
+		s := Simulation{Calculator: c, Model: RateModel{LongTermRate: 3, Reversion: 0.2}, Paths: 1}
+		got, err := s.Simulate()
+		tests.AssertNilError(t, err)
+		want := math.Round((3+2*math.Exp(-1))*100) / 100
+		tests.AssertSameFloat(t, got.Renewals[1].Rate.P50, want)
+	})
+
+	t.Run("should split the principal in equal payments when the rate falls to zero", func(t *testing.T) {
+		floor := RateModel{LongTermRate: 1, Reversion: 0.3, Volatility: 3}
+		s := Simulation{Calculator: c, Model: floor, Paths: 500, Seed: 1}
+		got, err := s.Simulate()
+		tests.AssertNilError(t, err)
+		for _, renewal := range got.Renewals[1:] {
+			tests.AssertSameFloat(t, renewal.Rate.P5, 0)
+			if renewal.Payment.P5 <= 0 || renewal.Payment.P5 > renewal.Payment.P95 {
+				t.Errorf("got %v, want positive ordered payments", renewal.Payment)
+			}
+		}
+		if got.TotalInterest.P5 < 0 {
+			t.Errorf("got %v, want a positive total interest", got.TotalInterest.P5)
+		}
+	})
+
+	t.Run("should return an error when the volatility is too high", func(t *testing.T) {
+		volatile := model
+		volatile.Volatility = 11
+		s := Simulation{Calculator: c, Model: volatile, Paths: 10}
+		_, err := s.Simulate()
+		fieldErrors := validate.GetFieldErrors(err)
+		if len(fieldErrors) == 0 || fieldErrors[0].Field != "volatility" {
+			t.Errorf("expected volatility got %v", err)
+		}
+	})
+
+	t.Run("should return an error when there are too many paths", func(t *testing.T) {
+		s := Simulation{Calculator: c, Model: model, Paths: 10001}
+		_, err := s.Simulate()
+		fieldError := validate.GetFieldErrors(err)[0]
+		if fieldError.Field != "paths" {
+			t.Errorf("expected paths got %s", fieldError.Field)
+		}
+	})
+}
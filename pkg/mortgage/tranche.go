@@ -0,0 +1,241 @@
+package mortgage
+
+import (
+	"errors"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"strings"
+)
+
+const (
+	Fixed    = "FIXED"
+	Variable = "VARIABLE"
+)
+
+// errors for multi tranche mortgages
+var (
+	ErrTrancheAmortizationOutOfRange = errors.New("tranche amortization period out of range")
+	ErrTermLongerThanAmortization    = errors.New("tranche term is longer than its amortization period")
+	ErrInvalidRateType               = errors.New("tranche rate type must be fixed or variable")
+)
+
+// Tranche is a segment of a mortgage carrying its own principal, of at least one cent, interest rate and amortization.
+// The amortization is given either in years or, for a mortgage part way through it, in months, never in both.
+type Tranche struct {
+	Name               string  `json:"name"`
+	Principal          float64 `json:"principal" validate:"required,gte=0.01"`
+	AnnualInterestRate float64 `json:"annualInterestRate" validate:"required,gt=0"`
+	AmortizationPeriod int     `json:"amortizationPeriod" validate:"required_without=AmortizationMonths,excluded_with=AmortizationMonths"`
+	AmortizationMonths int     `json:"amortizationMonths,omitempty" validate:"excluded_with=AmortizationPeriod,gte=0"`
+	Term               int     `json:"term,omitempty" validate:"gte=0"`
+	RateType           string  `json:"rateType,omitempty"`
+}
+
+// TrancheSummary holds the payment and amortization outputs of a single tranche.
+type TrancheSummary struct {
+	Name               string      `json:"name"`
+	RateType           string      `json:"rateType"`
+	Principal          money.Money `json:"principal"`
+	PaymentPerSchedule money.Money `json:"paymentPerSchedule"`
+	TermInterest       money.Money `json:"termInterest"`
+	BalanceAtTermEnd   money.Money `json:"balanceAtTermEnd"`
+	TotalInterest      money.Money `json:"totalInterest"`
+	Schedule           []Period    `json:"schedule"`
+}
+
+// Breakdown holds the outputs of every tranche and of the mortgage as a whole.
+type Breakdown struct {
+	Tranches           []TrancheSummary `json:"tranches"`
+	PaymentPerSchedule money.Money      `json:"paymentPerSchedule"`
+	TotalInterest      money.Money      `json:"totalInterest"`
+	Schedule           []Period         `json:"schedule"`
+}
+
+// Mortgage holds the tranches secured by the same property, all of them paid on a common schedule.
+type Mortgage struct {
+	Schedule string    `json:"schedule" validate:"required"`
+	Tranches []Tranche `json:"tranches" validate:"required,min=1,dive"`
+	Rounding string    `json:"rounding,omitempty"`
+}
+
+// PaymentSchedule returns the combined payment of every tranche according to the schedule.
+func (m Mortgage) PaymentSchedule() (money.Money, error) {
+	err := m.validate()
+	if err != nil {
+		return 0, err
+	}
+
+	rounding, err := money.ParseRoundingMode(m.Rounding)
+	if err != nil {
+		return 0, err
+	}
+
+	var total money.Money
+	for _, t := range m.Tranches {
+		payment, err := t.paymentSchedule(m.Schedule, rounding)
+		if err != nil {
+			return 0, err
+		}
+		total += payment
+	}
+
+	return total, nil
+}
+
+// AmortizationSchedule returns the combined amortization schedule of every tranche.
+func (m Mortgage) AmortizationSchedule() ([]Period, error) {
+	err := m.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	rounding, err := money.ParseRoundingMode(m.Rounding)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := make([][]Period, 0, len(m.Tranches))
+	for _, t := range m.Tranches {
+		schedule, err := t.amortizationSchedule(m.Schedule, rounding)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	return combineSchedules(schedules...), nil
+}
+
+// Breakdown returns the payment and amortization outputs of every tranche along with the aggregated mortgage outputs.
+func (m Mortgage) Breakdown() (Breakdown, error) {
+	err := m.validate()
+	if err != nil {
+		return Breakdown{}, err
+	}
+
+	rounding, err := money.ParseRoundingMode(m.Rounding)
+	if err != nil {
+		return Breakdown{}, err
+	}
+
+	var b Breakdown
+	schedules := make([][]Period, 0, len(m.Tranches))
+	for _, t := range m.Tranches {
+		summary, err := t.summary(m.Schedule, rounding)
+		if err != nil {
+			return Breakdown{}, err
+		}
+		b.Tranches = append(b.Tranches, summary)
+		b.PaymentPerSchedule += summary.PaymentPerSchedule
+		b.TotalInterest += summary.TotalInterest
+		schedules = append(schedules, summary.Schedule)
+	}
+
+	b.Schedule = combineSchedules(schedules...)
+	return b, nil
+}
+
+// validate checks the mortgage declared tags and the amortization period of every tranche.
+func (m Mortgage) validate() error {
+	err := validate.Check(m)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range m.Tranches {
+		if months := t.amortizationMonths(); months > maximumAmortizationPeriod*12 || months < 1 {
+			return ErrTrancheAmortizationOutOfRange
+		}
+		if t.Term*12 > t.amortizationMonths() {
+			return ErrTermLongerThanAmortization
+		}
+		if _, err := t.rateType(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// paymentSchedule returns the tranche payment according to the schedule.
+func (t Tranche) paymentSchedule(schedule string, rounding money.RoundingMode) (money.Money, error) {
+	numberOfPayments, err := paymentsInMonths(schedule, t.amortizationMonths())
+	if err != nil {
+		return 0, err
+	}
+
+	return annuityPayment(money.FromFloat(t.Principal), t.AnnualInterestRate, numberOfPayments, schedule, rounding)
+}
+
+// amortizationSchedule returns the amortization schedule of the tranche.
+func (t Tranche) amortizationSchedule(schedule string, rounding money.RoundingMode) ([]Period, error) {
+	payment, err := t.paymentSchedule(schedule, rounding)
+	if err != nil {
+		return nil, err
+	}
+
+	frequency, err := paymentFrequency(schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	return amortize(money.FromFloat(t.Principal), t.AnnualInterestRate, frequency, payment,
+		(frequency*t.amortizationMonths()+11)/12, rounding), nil
+}
+
+// summary returns the payment and amortization outputs of the tranche.
+func (t Tranche) summary(schedule string, rounding money.RoundingMode) (TrancheSummary, error) {
+	payment, err := t.paymentSchedule(schedule, rounding)
+	if err != nil {
+		return TrancheSummary{}, err
+	}
+
+	periods, err := t.amortizationSchedule(schedule, rounding)
+	if err != nil {
+		return TrancheSummary{}, err
+	}
+
+	frequency, err := paymentFrequency(schedule)
+	if err != nil {
+		return TrancheSummary{}, err
+	}
+
+	rateType, err := t.rateType()
+	if err != nil {
+		return TrancheSummary{}, err
+	}
+
+	termPeriods := periods
+	if n := frequency * t.Term; t.Term != 0 && n < len(periods) {
+		termPeriods = periods[:n]
+	}
+
+	return TrancheSummary{
+		Name:               t.Name,
+		RateType:           rateType,
+		Principal:          money.FromFloat(t.Principal),
+		PaymentPerSchedule: payment,
+		TermInterest:       totalInterest(termPeriods),
+		BalanceAtTermEnd:   termPeriods[len(termPeriods)-1].Balance,
+		TotalInterest:      totalInterest(periods),
+		Schedule:           periods,
+	}, nil
+}
+
+// amortizationMonths returns the tranche amortization in months, the months provided or the amortization period ones.
+func (t Tranche) amortizationMonths() int {
+	if t.AmortizationMonths != 0 {
+		return t.AmortizationMonths
+	}
+	return t.AmortizationPeriod * 12
+}
+
+// rateType returns the tranche rate type, tranches are fixed rate unless stated otherwise.
+func (t Tranche) rateType() (string, error) {
+	switch strings.ToUpper(t.RateType) {
+	case "", Fixed:
+		return Fixed, nil
+	case Variable:
+		return Variable, nil
+	}
+	return "", ErrInvalidRateType
+}
@@ -0,0 +1,224 @@
+package mortgage
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"testing"
+)
+
+func TestMortgagePaymentSchedule(t *testing.T) {
+	t.Run("should add up the payment of every tranche", func(t *testing.T) {
+		m := Mortgage{
+			Schedule: Monthly,
+			Tranches: []Tranche{
+				{Principal: 98800, AnnualInterestRate: 4.29, AmortizationPeriod: 5},
+				{Principal: 98800, AnnualInterestRate: 4.29, AmortizationPeriod: 5},
+			},
+		}
+
+		got, err := m.PaymentSchedule()
+		AssertMoneyValuesAndNilError(t, err, got, 3665.02)
+	})
+
+	t.Run("a single tranche mortgage should match the calculator payment", func(t *testing.T) {
+		m := Mortgage{
+			Schedule: Biweekly,
+			Tranches: []Tranche{{Principal: 98800, AnnualInterestRate: 4.29, AmortizationPeriod: 5}},
+		}
+
+		got, err := m.PaymentSchedule()
+		AssertMoneyValuesAndNilError(t, err, got, 845.05)
+	})
+
+	t.Run("should return a error if the mortgage does not pass validation check", func(t *testing.T) {
+		m := Mortgage{Schedule: Monthly}
+
+		_, err := m.PaymentSchedule()
+		fieldError := validate.GetFieldErrors(err)[0]
+
+		wantedField := "tranches"
+		if fieldError.Field != wantedField {
+			t.Errorf("expected %s got %s", wantedField, fieldError.Field)
+		}
+	})
+
+	t.Run("should amortize a tranche over the months remaining", func(t *testing.T) {
+		m := Mortgage{
+			Schedule: Monthly,
+			Tranches: []Tranche{{Principal: 300000, AnnualInterestRate: 2.5, AmortizationMonths: 234}},
+		}
+
+		got, err := m.PaymentSchedule()
+		AssertMoneyValuesAndNilError(t, err, got, 1621.15)
+	})
+
+	t.Run("should match the amortization period when the months are whole years", func(t *testing.T) {
+		m := Mortgage{
+			Schedule: Monthly,
+			Tranches: []Tranche{{Principal: 300000, AnnualInterestRate: 2.5, AmortizationMonths: 240}},
+		}
+
+		got, err := m.PaymentSchedule()
+		AssertMoneyValuesAndNilError(t, err, got, 1589.71)
+	})
+
+	t.Run("should return a error if a tranche amortization in months is out of range", func(t *testing.T) {
+		m := Mortgage{
+			Schedule: Monthly,
+			Tranches: []Tranche{{Principal: 300000, AnnualInterestRate: 2.5, AmortizationMonths: 361}},
+		}
+
+		_, err := m.PaymentSchedule()
+		tests.AssertEqualErrors(t, err, ErrTrancheAmortizationOutOfRange)
+	})
+
+	t.Run("should return a error if a tranche amortization period is out of range", func(t *testing.T) {
+		m := Mortgage{
+			Schedule: Monthly,
+			Tranches: []Tranche{{Principal: 98800, AnnualInterestRate: 4.29, AmortizationPeriod: 31}},
+		}
+
+		_, err := m.PaymentSchedule()
+		tests.AssertEqualErrors(t, err, ErrTrancheAmortizationOutOfRange)
+	})
+
+	t.Run("should return a error if the schedule is not supported", func(t *testing.T) {
+		m := Mortgage{
+			Schedule: "yearly",
+			Tranches: []Tranche{{Principal: 98800, AnnualInterestRate: 4.29, AmortizationPeriod: 5}},
+		}
+
+		_, err := m.PaymentSchedule()
+		tests.AssertEqualErrors(t, err, errInvalidSchedule)
+	})
+}
+
+func TestMortgageAmortizationSchedule(t *testing.T) {
+	m := Mortgage{
+		Schedule: Monthly,
+		Tranches: []Tranche{
+			{Principal: 300000, AnnualInterestRate: 2.5, AmortizationPeriod: 20},
+			{Principal: 100000, AnnualInterestRate: 5, AmortizationPeriod: 25},
+		},
+	}
+
+	t.Run("should last as long as the longest tranche", func(t *testing.T) {
+		got, err := m.AmortizationSchedule()
+		tests.AssertNilError(t, err)
+		tests.AssertSameInt(t, len(got), 300)
+		tests.AssertSameMoney(t, got[len(got)-1].Balance, 0)
+	})
+
+	t.Run("should combine the payments while every tranche is being paid", func(t *testing.T) {
+		got, err := m.AmortizationSchedule()
+		tests.AssertNilError(t, err)
+		AssertPeriod(t, got[0], newPeriod(1, 2174.3, 1041.67, 1132.63, 398867.37))
+	})
+
+	t.Run("should last the months remaining of a tranche", func(t *testing.T) {
+		for schedule, want := range map[string]int{Monthly: 234, Biweekly: 507} {
+			partial := Mortgage{
+				Schedule: schedule,
+				Tranches: []Tranche{{Principal: 300000, AnnualInterestRate: 2.5, AmortizationMonths: 234}},
+			}
+
+			got, err := partial.AmortizationSchedule()
+			tests.AssertNilError(t, err)
+			tests.AssertSameInt(t, len(got), want)
+			tests.AssertSameMoney(t, got[len(got)-1].Balance, 0)
+		}
+	})
+
+	t.Run("should only include the remaining tranche once the shortest is paid", func(t *testing.T) {
+		got, err := m.AmortizationSchedule()
+		tests.AssertNilError(t, err)
+		AssertPeriod(t, got[240], newPeriod(241, 584.59, 129.07, 455.52, 30522.34))
+	})
+}
+
+func TestMortgageBreakdown(t *testing.T) {
+	m := Mortgage{
+		Schedule: Monthly,
+		Tranches: []Tranche{
+			{Name: "fixed", Principal: 300000, AnnualInterestRate: 4.29, AmortizationPeriod: 25, Term: 5},
+			{Name: "variable", Principal: 100000, AnnualInterestRate: 5.2, AmortizationPeriod: 10, Term: 3, RateType: "variable"},
+		},
+	}
+
+	t.Run("should return the outputs of every tranche", func(t *testing.T) {
+		got, err := m.Breakdown()
+		tests.AssertNilError(t, err)
+		tests.AssertSameInt(t, len(got.Tranches), 2)
+
+		fixed := got.Tranches[0]
+		if fixed.RateType != Fixed {
+			t.Errorf("got %s want %s", fixed.RateType, Fixed)
+		}
+		tests.AssertSameMoney(t, fixed.PaymentPerSchedule, 1631.94)
+		tests.AssertSameMoney(t, fixed.TermInterest, 60552.3)
+		tests.AssertSameMoney(t, fixed.BalanceAtTermEnd, 262635.9)
+		tests.AssertSameMoney(t, fixed.TotalInterest, 189582.26)
+		tests.AssertSameInt(t, len(fixed.Schedule), 300)
+
+		variable := got.Tranches[1]
+		if variable.RateType != Variable {
+			t.Errorf("got %s want %s", variable.RateType, Variable)
+		}
+		tests.AssertSameMoney(t, variable.PaymentPerSchedule, 1070.46)
+		tests.AssertSameMoney(t, variable.TermInterest, 13772.05)
+		tests.AssertSameMoney(t, variable.BalanceAtTermEnd, 75235.49)
+		tests.AssertSameInt(t, len(variable.Schedule), 120)
+	})
+
+	t.Run("should aggregate the outputs of every tranche", func(t *testing.T) {
+		got, err := m.Breakdown()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.PaymentPerSchedule, 2702.4)
+		tests.AssertSameMoney(t, got.TotalInterest, 218037.1)
+		tests.AssertSameInt(t, len(got.Schedule), 300)
+	})
+
+	t.Run("should consider the whole amortization as the term when the term is not provided", func(t *testing.T) {
+		single := Mortgage{Schedule: Monthly, Tranches: []Tranche{{Principal: 100000, AnnualInterestRate: 5, AmortizationPeriod: 5}}}
+
+		got, err := single.Breakdown()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.Tranches[0].TermInterest, got.Tranches[0].TotalInterest.Float64())
+		tests.AssertSameMoney(t, got.Tranches[0].BalanceAtTermEnd, 0)
+	})
+
+	t.Run("should return a error if the term is longer than the amortization period", func(t *testing.T) {
+		invalid := Mortgage{Schedule: Monthly, Tranches: []Tranche{{Principal: 100000, AnnualInterestRate: 5, AmortizationPeriod: 5, Term: 10}}}
+
+		_, err := invalid.Breakdown()
+		tests.AssertEqualErrors(t, err, ErrTermLongerThanAmortization)
+	})
+
+	t.Run("should return a error if the rate type is not supported", func(t *testing.T) {
+		invalid := Mortgage{Schedule: Monthly, Tranches: []Tranche{{Principal: 100000, AnnualInterestRate: 5, AmortizationPeriod: 5, RateType: "hybrid"}}}
+
+		_, err := invalid.Breakdown()
+		tests.AssertEqualErrors(t, err, ErrInvalidRateType)
+	})
+
+	t.Run("should return a error if a tranche principal is less than a cent", func(t *testing.T) {
+		invalid := Mortgage{Schedule: Monthly, Tranches: []Tranche{{Principal: 0.001, AnnualInterestRate: 5, AmortizationPeriod: 5}}}
+
+		_, err := invalid.Breakdown()
+		fieldErrors := validate.GetFieldErrors(err)
+		if len(fieldErrors) == 0 || fieldErrors[0].Field != "principal" {
+			t.Errorf("expected a principal field error got %v", err)
+		}
+	})
+
+	t.Run("should return a error if a tranche sends both the amortization years and months", func(t *testing.T) {
+		tranche := Tranche{Principal: 100000, AnnualInterestRate: 5, AmortizationPeriod: 25, AmortizationMonths: 234}
+		invalid := Mortgage{Schedule: Monthly, Tranches: []Tranche{tranche}}
+
+		_, err := invalid.Breakdown()
+		fieldErrors := validate.GetFieldErrors(err)
+		if len(fieldErrors) == 0 || fieldErrors[0].Field != "amortizationPeriod" {
+			t.Errorf("expected a amortizationPeriod field error got %v", err)
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package propertytax
+
+import "github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+
+// Amounts of the BC Home Owner Grant in dollars, the reduction rate is a percentage of the value over the threshold.
+const (
+	basicGrant                = 570
+	northernRuralBenefit      = 200
+	additionalGrant           = 845
+	grantThreshold            = 2_150_000
+	grantReductionRate        = 0.5
+	minimumTaxAfterBasicGrant = 350
+	minimumTaxAfterAdditional = 100
+)
+
+// homeOwnerGrant returns the home owner grant deducted from the tax of a home with the assessed value. The basic grant
+// is replaced by the additional grant for seniors and persons with disabilities and both get the northern and rural
+// area benefit outside the Capital, Metro Vancouver and Fraser Valley regional districts. The grant is reduced by $5
+// for every $1,000 of value over the threshold and can not bring the tax below the minimum tax.
+func homeOwnerGrant(value, tax money.Money, northernRural, additional bool, rounding money.RoundingMode) money.Money {
+	grant, minimumTax := money.FromFloat(basicGrant), money.FromFloat(minimumTaxAfterBasicGrant)
+	if additional {
+		grant, minimumTax = money.FromFloat(additionalGrant), money.FromFloat(minimumTaxAfterAdditional)
+	}
+	if northernRural {
+		grant += money.FromFloat(northernRuralBenefit)
+	}
+
+	if over := value - money.FromFloat(grantThreshold); over > 0 {
+		grant -= over.MulRate(grantReductionRate, 1, rounding)
+	}
+
+	if maximum := tax - minimumTax; grant > maximum {
+		grant = maximum
+	}
+	if grant < 0 {
+		return 0
+	}
+	return grant
+}
@@ -0,0 +1,36 @@
+package propertytax
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"testing"
+)
+
+func TestHomeOwnerGrant(t *testing.T) {
+	cases := []struct {
+		name          string
+		value         float64
+		tax           float64
+		northernRural bool
+		additional    bool
+		want          float64
+	}{
+		{"basic grant", 1_000_000, 2803.60, false, false, 570},
+		{"additional grant", 1_000_000, 2803.60, false, true, 845},
+		{"northern and rural area benefit", 400_000, 3480.48, true, false, 770},
+		{"additional grant with the northern and rural area benefit", 400_000, 3480.48, true, true, 1045},
+		{"reduced over the threshold", 2_200_000, 6167.92, false, false, 320},
+		{"phased out over the threshold", 2_300_000, 6448.28, false, false, 0},
+		{"limited by the minimum tax", 300_000, 675.51, false, false, 325.51},
+		{"limited by the minimum tax of the additional grant", 300_000, 675.51, false, true, 575.51},
+		{"none when the tax is under the minimum tax", 100_000, 280.36, false, false, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := homeOwnerGrant(money.FromFloat(c.value), money.FromFloat(c.tax), c.northernRural, c.additional,
+				money.HalfUp)
+			tests.AssertSameMoney(t, got, c.want)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Package propertytax estimates the yearly property tax of a BC home from the residential mill rate of its
+// municipality and the BC Home Owner Grant.
+package propertytax
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"os"
+	"strings"
+)
+
+// errors for property tax estimates
+var (
+	ErrUnknownMunicipality = errors.New("municipality not found in the mill rates table")
+)
+
+// defaultTable is the embedded table of residential mill rates of BC municipalities.
+//
+//go:embed municipalities.json
+var defaultTable []byte
+
+// Municipality holds the residential mill rate of a municipality, the tax in dollars per $1,000 of assessed value
+// including the school and regional taxes, whether it is in the northern and rural area of the province or in an area
+// designated for the speculation and vacancy tax, and the rate of its own empty homes tax if it has one.
+type Municipality struct {
+	Name                  string  `json:"name"`
+	MillRate              float64 `json:"millRate"`
+	NorthernRural         bool    `json:"northernRural"`
+	SpeculationVacancyTax bool    `json:"speculationVacancyTax"`
+	EmptyHomesTaxRate     float64 `json:"emptyHomesTaxRate,omitempty"`
+}
+
+// table holds the mill rates of a tax year.
+type table struct {
+	Year           int            `json:"year"`
+	Municipalities []Municipality `json:"municipalities"`
+}
+
+// municipalities holds the municipalities of the mill rates table in use by their lower case name.
+var municipalities = mustParse(defaultTable)
+
+// LoadMunicipalities replaces the embedded mill rates table with the one in the JSON file at the path. It is meant to
+// be called once on startup, before any estimate.
+func LoadMunicipalities(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	m, err := parse(data)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	municipalities = m
+	return nil
+}
+
+// LookupMunicipality returns the municipality with the name, ignoring its case.
+func LookupMunicipality(name string) (Municipality, error) {
+	m, ok := municipalities[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return Municipality{}, ErrUnknownMunicipality
+	}
+	return m, nil
+}
+
+// parse returns the municipalities of the mill rates table by their lower case name.
+func parse(data []byte) (map[string]Municipality, error) {
+	var t table
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]Municipality, len(t.Municipalities))
+	for _, municipality := range t.Municipalities {
+		if municipality.Name == "" || municipality.MillRate <= 0 {
+			return nil, fmt.Errorf("invalid municipality %q with mill rate %v", municipality.Name, municipality.MillRate)
+		}
+		m[strings.ToLower(municipality.Name)] = municipality
+	}
+	return m, nil
+}
+
+// mustParse returns the municipalities of the mill rates table and panics when it is not valid.
+func mustParse(data []byte) map[string]Municipality {
+	m, err := parse(data)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Property holds the properties of a home its property tax depends on. The home owner grant is only available when
+// the home is the principal residence of its owner, seniors and persons with disabilities get the additional grant.
+type Property struct {
+	AssessedValue      float64 `json:"assessedValue" validate:"required,gt=0"`
+	Municipality       string  `json:"municipality" validate:"required"`
+	PrincipalResidence bool    `json:"principalResidence"`
+	Senior             bool    `json:"senior"`
+	Disabled           bool    `json:"disabled"`
+	Rounding           string  `json:"rounding,omitempty"`
+}
+
+// Estimate holds the yearly property tax before and after the home owner grant.
+type Estimate struct {
+	MillRate       float64     `json:"millRate"`
+	GrossTax       money.Money `json:"grossTax"`
+	HomeOwnerGrant money.Money `json:"homeOwnerGrant"`
+	NetTax         money.Money `json:"netTax"`
+}
+
+// Estimate returns the yearly property tax of the home at the mill rate of its municipality, less the home owner grant.
+func (p Property) Estimate() (Estimate, error) {
+	err := validate.Check(p)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	municipality, err := LookupMunicipality(p.Municipality)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	rounding, err := money.ParseRoundingMode(p.Rounding)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	value := money.FromFloat(p.AssessedValue)
+	// The mill rate is per $1,000 of value, a tenth of a percentage.
+	tax := value.MulRate(municipality.MillRate, 10, rounding)
+
+	var grant money.Money
+	if p.PrincipalResidence {
+		grant = homeOwnerGrant(value, tax, municipality.NorthernRural, p.Senior || p.Disabled, rounding)
+	}
+
+	return Estimate{
+		MillRate:       municipality.MillRate,
+		GrossTax:       tax,
+		HomeOwnerGrant: grant,
+		NetTax:         tax - grant,
+	}, nil
+}
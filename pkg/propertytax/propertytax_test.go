@@ -0,0 +1,75 @@
+package propertytax
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEstimate(t *testing.T) {
+	p := Property{AssessedValue: 1_000_000, Municipality: "Vancouver", PrincipalResidence: true}
+
+	t.Run("should return the tax at the mill rate less the home owner grant", func(t *testing.T) {
+		got, err := p.Estimate()
+		tests.AssertNilError(t, err)
+		tests.AssertSameFloat(t, got.MillRate, 2.8036)
+		tests.AssertSameMoney(t, got.GrossTax, 2803.60)
+		tests.AssertSameMoney(t, got.HomeOwnerGrant, 570)
+		tests.AssertSameMoney(t, got.NetTax, 2233.60)
+	})
+
+	t.Run("should apply the additional grant to seniors and persons with disabilities", func(t *testing.T) {
+		for _, additional := range []Property{{Senior: true}, {Disabled: true}} {
+			additional.AssessedValue, additional.Municipality, additional.PrincipalResidence = 1_000_000, "vancouver", true
+			got, err := additional.Estimate()
+			tests.AssertNilError(t, err)
+			tests.AssertSameMoney(t, got.NetTax, 1958.60)
+		}
+	})
+
+	t.Run("should not apply the grant when the home is not a principal residence", func(t *testing.T) {
+		rental := p
+		rental.PrincipalResidence = false
+		got, err := rental.Estimate()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.NetTax, 2803.60)
+	})
+
+	t.Run("should return an error when the municipality is unknown", func(t *testing.T) {
+		unknown := p
+		unknown.Municipality = "Atlantis"
+		_, err := unknown.Estimate()
+		tests.AssertEqualErrors(t, err, ErrUnknownMunicipality)
+	})
+}
+
+func TestLoadMunicipalities(t *testing.T) {
+	defer func() { municipalities = mustParse(defaultTable) }()
+
+	t.Run("should replace the mill rates table", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "municipalities.json")
+		data := `{"year": 2025, "municipalities": [{"name": "Atlantis", "millRate": 5}]}`
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		tests.AssertNilError(t, LoadMunicipalities(path))
+		got, err := LookupMunicipality("atlantis")
+		tests.AssertNilError(t, err)
+		tests.AssertSameFloat(t, got.MillRate, 5)
+		_, err = LookupMunicipality("Vancouver")
+		tests.AssertEqualErrors(t, err, ErrUnknownMunicipality)
+	})
+
+	t.Run("should return an error when a mill rate is missing", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "municipalities.json")
+		if err := os.WriteFile(path, []byte(`{"municipalities": [{"name": "Atlantis"}]}`), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := LoadMunicipalities(path); err == nil {
+			t.Errorf("got nil, want an error")
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package propertytax
+
+import (
+	"errors"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"strings"
+)
+
+// Residency statuses of the owner of a property
+const (
+	Canadian        = "CANADIAN"
+	Foreign         = "FOREIGN"
+	SatelliteFamily = "SATELLITEFAMILY"
+)
+
+// Rules of the speculation and vacancy tax, the rates are percentages of the assessed value.
+const (
+	canadianSpeculationVacancyRate = 1
+	foreignSpeculationVacancyRate  = 3
+	minimumMonthsRented            = 6
+)
+
+// errors for vacancy taxes
+var (
+	ErrInvalidResidency = errors.New("residency must be canadian, foreign or satelliteFamily")
+)
+
+// Occupancy holds how a property is used during the year and the residency status of its owner, a Canadian citizen or
+// permanent resident when not provided. Satellite families are those earning most of their income outside Canada.
+type Occupancy struct {
+	Residency          string `json:"residency,omitempty"`
+	PrincipalResidence bool   `json:"principalResidence"`
+	MonthsRented       int    `json:"monthsRented" validate:"gte=0,lte=12"`
+}
+
+// Vacancy holds the properties of a home its vacancy taxes depend on.
+type Vacancy struct {
+	Occupancy
+	AssessedValue float64 `json:"assessedValue" validate:"required,gt=0"`
+	Municipality  string  `json:"municipality" validate:"required"`
+	Rounding      string  `json:"rounding,omitempty"`
+}
+
+// VacancyTaxes holds the yearly BC speculation and vacancy tax and the municipal empty homes tax of a property.
+type VacancyTaxes struct {
+	SpeculationVacancyTax money.Money `json:"speculationVacancyTax"`
+	EmptyHomesTax         money.Money `json:"emptyHomesTax"`
+	Total                 money.Money `json:"total"`
+}
+
+// Taxes returns the yearly vacancy taxes of the property. Neither tax is owed on a principal residence or a property
+// rented for at least 6 months of the year, the speculation and vacancy tax is only owed in the designated areas.
+func (v Vacancy) Taxes() (VacancyTaxes, error) {
+	err := validate.Check(v)
+	if err != nil {
+		return VacancyTaxes{}, err
+	}
+
+	municipality, err := LookupMunicipality(v.Municipality)
+	if err != nil {
+		return VacancyTaxes{}, err
+	}
+
+	rounding, err := money.ParseRoundingMode(v.Rounding)
+	if err != nil {
+		return VacancyTaxes{}, err
+	}
+
+	return v.Occupancy.taxes(money.FromFloat(v.AssessedValue), municipality, rounding)
+}
+
+// VacancyTaxes returns the yearly vacancy taxes of a property in the municipality assessed at the value.
+func (o Occupancy) VacancyTaxes(value money.Money, municipality string, rounding money.RoundingMode) (VacancyTaxes, error) {
+	m, err := LookupMunicipality(municipality)
+	if err != nil {
+		return VacancyTaxes{}, err
+	}
+	return o.taxes(value, m, rounding)
+}
+
+// taxes returns the yearly vacancy taxes of a property in the municipality assessed at the value.
+func (o Occupancy) taxes(value money.Money, municipality Municipality, rounding money.RoundingMode) (VacancyTaxes, error) {
+	rate, err := o.speculationVacancyRate()
+	if err != nil {
+		return VacancyTaxes{}, err
+	}
+
+	if o.PrincipalResidence || o.MonthsRented >= minimumMonthsRented {
+		return VacancyTaxes{}, nil
+	}
+
+	var taxes VacancyTaxes
+	if municipality.SpeculationVacancyTax {
+		taxes.SpeculationVacancyTax = value.MulRate(rate, 1, rounding)
+	}
+	taxes.EmptyHomesTax = value.MulRate(municipality.EmptyHomesTaxRate, 1, rounding)
+	taxes.Total = taxes.SpeculationVacancyTax + taxes.EmptyHomesTax
+	return taxes, nil
+}
+
+// speculationVacancyRate returns the speculation and vacancy tax rate of the residency status of the owner.
+func (o Occupancy) speculationVacancyRate() (float64, error) {
+	switch strings.ToUpper(o.Residency) {
+	case "", Canadian:
+		return canadianSpeculationVacancyRate, nil
+	case Foreign, SatelliteFamily:
+		return foreignSpeculationVacancyRate, nil
+	}
+	return 0, ErrInvalidResidency
+}
@@ -0,0 +1,62 @@
+package propertytax
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"testing"
+)
+
+func TestVacancyTaxes(t *testing.T) {
+	v := Vacancy{AssessedValue: 1_000_000, Municipality: "Vancouver"}
+
+	t.Run("should return the speculation and vacancy tax and the empty homes tax of a vacant home", func(t *testing.T) {
+		got, err := v.Taxes()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.SpeculationVacancyTax, 10000)
+		tests.AssertSameMoney(t, got.EmptyHomesTax, 30000)
+		tests.AssertSameMoney(t, got.Total, 40000)
+	})
+
+	t.Run("should charge the higher rate to foreign owners and satellite families", func(t *testing.T) {
+		for _, residency := range []string{Foreign, "satelliteFamily"} {
+			foreign := v
+			foreign.Residency = residency
+			got, err := foreign.Taxes()
+			tests.AssertNilError(t, err)
+			tests.AssertSameMoney(t, got.SpeculationVacancyTax, 30000)
+		}
+	})
+
+	t.Run("should exempt principal residences and homes rented for 6 months", func(t *testing.T) {
+		for _, occupancy := range []Occupancy{{PrincipalResidence: true}, {MonthsRented: 6}} {
+			exempt := v
+			exempt.Occupancy = occupancy
+			got, err := exempt.Taxes()
+			tests.AssertNilError(t, err)
+			tests.AssertSameMoney(t, got.Total, 0)
+		}
+	})
+
+	t.Run("should not charge the empty homes tax outside Vancouver", func(t *testing.T) {
+		burnaby := v
+		burnaby.Municipality = "Burnaby"
+		got, err := burnaby.Taxes()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.SpeculationVacancyTax, 10000)
+		tests.AssertSameMoney(t, got.EmptyHomesTax, 0)
+	})
+
+	t.Run("should not charge the speculation and vacancy tax outside the designated areas", func(t *testing.T) {
+		kamloops := v
+		kamloops.Municipality = "Kamloops"
+		got, err := kamloops.Taxes()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.Total, 0)
+	})
+
+	t.Run("should return an error when the residency is invalid", func(t *testing.T) {
+		invalid := v
+		invalid.Residency = "tourist"
+		_, err := invalid.Taxes()
+		tests.AssertEqualErrors(t, err, ErrInvalidResidency)
+	})
+}
@@ -0,0 +1,50 @@
+package province
+
+import "github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+
+// Limits of the BC first time home buyers' exemption, in dollars.
+const (
+	bcFirstTimeBuyerExemptionUpTo  = 500_000
+	bcFirstTimeBuyerFullExemption  = 835_000
+	bcFirstTimeBuyerExemptionLimit = 860_000
+)
+
+// bcPropertyTransferTax are the BC property transfer tax brackets.
+var bcPropertyTransferTax = []bracket{
+	{upTo: 200_000, rate: 1},
+	{upTo: 2_000_000, rate: 2},
+	{upTo: 3_000_000, rate: 3},
+	{rate: 5},
+}
+
+// britishColumbia holds the rules of British Columbia.
+type britishColumbia struct{}
+
+// TransferTaxes returns the BC property transfer tax. First time buyers are exempted from the tax on the first
+// $500,000 of properties up to $835,000, the exemption is phased out up to $860,000.
+func (britishColumbia) TransferTaxes(price money.Money, _ string, firstTimeBuyer bool,
+	rounding money.RoundingMode) TransferTaxes {
+	tax := taxBrackets(price, bcPropertyTransferTax, rounding)
+
+	var exemption money.Money
+	if firstTimeBuyer && price < money.FromFloat(bcFirstTimeBuyerExemptionLimit) {
+		exempted := price
+		if limit := money.FromFloat(bcFirstTimeBuyerExemptionUpTo); exempted > limit {
+			exempted = limit
+		}
+		exemption = taxBrackets(exempted, bcPropertyTransferTax, rounding)
+
+		if full := money.FromFloat(bcFirstTimeBuyerFullExemption); price > full {
+			remaining := float64(money.FromFloat(bcFirstTimeBuyerExemptionLimit) - price)
+			phaseOut := float64(money.FromFloat(bcFirstTimeBuyerExemptionLimit) - full)
+			exemption = exemption.MulRate(remaining/phaseOut*100, 1, rounding)
+		}
+	}
+
+	return transferTaxes(tax, 0, exemption)
+}
+
+// PremiumTaxRate returns zero, BC does not charge sales tax on insurance premiums.
+func (britishColumbia) PremiumTaxRate() float64 {
+	return 0
+}
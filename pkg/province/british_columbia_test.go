@@ -0,0 +1,38 @@
+package province
+
+import (
+	"fmt"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"testing"
+)
+
+func TestBritishColumbiaTransferTaxes(t *testing.T) {
+	cases := []struct {
+		price          float64
+		firstTimeBuyer bool
+		tax            float64
+		rebate         float64
+	}{
+		{150000, false, 1500, 0},
+		{500000, false, 8000, 0},
+		{2500000, false, 53000, 0},
+		{4000000, false, 118000, 0},
+		{450000, true, 7000, 7000},
+		{700000, true, 12000, 8000},
+		{847500, true, 14950, 4000},
+		{860000, true, 15200, 0},
+	}
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("should return %.2f tax and %.2f exemption for %.2f", tc.tax, tc.rebate, tc.price), func(t *testing.T) {
+			got := britishColumbia{}.TransferTaxes(money.FromFloat(tc.price), "", tc.firstTimeBuyer, money.HalfUp)
+			tests.AssertSameMoney(t, got.LandTransferTax, tc.tax)
+			tests.AssertSameMoney(t, got.FirstTimeBuyerRebate, tc.rebate)
+			tests.AssertSameMoney(t, got.Total, tc.tax-tc.rebate)
+		})
+	}
+
+	t.Run("should not charge tax on the insurance premium", func(t *testing.T) {
+		tests.AssertSameFloat(t, britishColumbia{}.PremiumTaxRate(), 0)
+	})
+}
@@ -0,0 +1,74 @@
+package province
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"strings"
+)
+
+// First time buyer rebates of Ontario and Toronto, in dollars.
+const (
+	onFirstTimeBuyerRebate      = 4_000
+	torontoFirstTimeBuyerRebate = 4_475
+)
+
+// toronto is the municipality charging a municipal land transfer tax in Ontario.
+const toronto = "TORONTO"
+
+// onLandTransferTax are the Ontario land transfer tax brackets of single family residences.
+var onLandTransferTax = []bracket{
+	{upTo: 55_000, rate: 0.5},
+	{upTo: 250_000, rate: 1},
+	{upTo: 400_000, rate: 1.5},
+	{upTo: 2_000_000, rate: 2},
+	{rate: 2.5},
+}
+
+// torontoLandTransferTax are the Toronto municipal land transfer tax brackets of single family residences.
+var torontoLandTransferTax = []bracket{
+	{upTo: 55_000, rate: 0.5},
+	{upTo: 250_000, rate: 1},
+	{upTo: 400_000, rate: 1.5},
+	{upTo: 2_000_000, rate: 2},
+	{upTo: 3_000_000, rate: 2.5},
+	{upTo: 4_000_000, rate: 3.5},
+	{upTo: 5_000_000, rate: 4.5},
+	{upTo: 10_000_000, rate: 5.5},
+	{upTo: 20_000_000, rate: 6.5},
+	{rate: 7.5},
+}
+
+// ontario holds the rules of Ontario.
+type ontario struct{}
+
+// TransferTaxes returns the Ontario land transfer tax and, in Toronto, the municipal land transfer tax. First time
+// buyers get a rebate of up to $4,000 on the provincial tax and up to $4,475 on the municipal one.
+func (ontario) TransferTaxes(price money.Money, municipality string, firstTimeBuyer bool,
+	rounding money.RoundingMode) TransferTaxes {
+	provincial := taxBrackets(price, onLandTransferTax, rounding)
+
+	var municipal money.Money
+	if strings.ToUpper(municipality) == toronto {
+		municipal = taxBrackets(price, torontoLandTransferTax, rounding)
+	}
+
+	var rebate money.Money
+	if firstTimeBuyer {
+		rebate = smallest(provincial, money.FromFloat(onFirstTimeBuyerRebate)) +
+			smallest(municipal, money.FromFloat(torontoFirstTimeBuyerRebate))
+	}
+
+	return transferTaxes(provincial, municipal, rebate)
+}
+
+// PremiumTaxRate returns the Ontario retail sales tax charged on insurance premiums.
+func (ontario) PremiumTaxRate() float64 {
+	return 8
+}
+
+// smallest returns the smallest of two amounts.
+func smallest(a, b money.Money) money.Money {
+	if a < b {
+		return a
+	}
+	return b
+}
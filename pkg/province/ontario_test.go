@@ -0,0 +1,39 @@
+package province
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"testing"
+)
+
+func TestOntarioTransferTaxes(t *testing.T) {
+	t.Run("should return the provincial land transfer tax", func(t *testing.T) {
+		got := ontario{}.TransferTaxes(money.FromFloat(500000), "Ottawa", false, money.HalfUp)
+		tests.AssertSameMoney(t, got.LandTransferTax, 6475)
+		tests.AssertSameMoney(t, got.MunicipalLandTransferTax, 0)
+		tests.AssertSameMoney(t, got.Total, 6475)
+
+		got = ontario{}.TransferTaxes(money.FromFloat(2500000), "", false, money.HalfUp)
+		tests.AssertSameMoney(t, got.LandTransferTax, 48975)
+	})
+
+	t.Run("should add the municipal land transfer tax in toronto", func(t *testing.T) {
+		got := ontario{}.TransferTaxes(money.FromFloat(3500000), "toronto", false, money.HalfUp)
+		tests.AssertSameMoney(t, got.LandTransferTax, 73975)
+		tests.AssertSameMoney(t, got.MunicipalLandTransferTax, 78975)
+	})
+
+	t.Run("should deduct the first time buyer rebates", func(t *testing.T) {
+		got := ontario{}.TransferTaxes(money.FromFloat(500000), "Toronto", true, money.HalfUp)
+		tests.AssertSameMoney(t, got.FirstTimeBuyerRebate, 8475)
+		tests.AssertSameMoney(t, got.Total, 12950-8475)
+
+		got = ontario{}.TransferTaxes(money.FromFloat(300000), "", true, money.HalfUp)
+		tests.AssertSameMoney(t, got.FirstTimeBuyerRebate, 2975)
+		tests.AssertSameMoney(t, got.Total, 0)
+	})
+
+	t.Run("should charge tax on the insurance premium", func(t *testing.T) {
+		tests.AssertSameFloat(t, ontario{}.PremiumTaxRate(), 8)
+	})
+}
@@ -0,0 +1,127 @@
+// Package province provides the rules that change from one Canadian province to another when buying a property, such
+// as the land transfer taxes and the sales tax charged on the mortgage default insurance premium.
+package province
+
+import (
+	"errors"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"strings"
+)
+
+// Supported provinces
+const (
+	BritishColumbia = "BC"
+	Ontario         = "ON"
+	Quebec          = "QC"
+	Saskatchewan    = "SK"
+)
+
+// errors for province rules
+var (
+	ErrUnsupportedProvince = errors.New("province must be BC, ON, QC or SK")
+)
+
+// Rules defines the rules of a province when buying a property.
+type Rules interface {
+	// TransferTaxes returns the taxes paid to register the transfer of a property bought at the price in the
+	// municipality, along with the rebates a first time buyer is entitled to.
+	TransferTaxes(price money.Money, municipality string, firstTimeBuyer bool, rounding money.RoundingMode) TransferTaxes
+
+	// PremiumTaxRate returns the percentage of provincial sales tax charged on the default insurance premium.
+	PremiumTaxRate() float64
+}
+
+// rules holds the rules of every supported province.
+var rules = map[string]Rules{
+	BritishColumbia: britishColumbia{},
+	Ontario:         ontario{},
+	Quebec:          quebec{},
+	Saskatchewan:    saskatchewan{},
+}
+
+// Lookup returns the rules of the province with the code, British Columbia when the code is empty.
+func Lookup(code string) (Rules, error) {
+	if code == "" {
+		code = BritishColumbia
+	}
+	r, ok := rules[strings.ToUpper(code)]
+	if !ok {
+		return nil, ErrUnsupportedProvince
+	}
+	return r, nil
+}
+
+// TransferTaxes holds the provincial and municipal land transfer taxes and the first time buyer rebates deducted from
+// them.
+type TransferTaxes struct {
+	LandTransferTax          money.Money `json:"landTransferTax"`
+	MunicipalLandTransferTax money.Money `json:"municipalLandTransferTax"`
+	FirstTimeBuyerRebate     money.Money `json:"firstTimeBuyerRebate"`
+	Total                    money.Money `json:"total"`
+}
+
+// Purchase holds the properties of a purchase the transfer taxes depend on.
+type Purchase struct {
+	Province       string  `json:"province,omitempty"`
+	Price          float64 `json:"price" validate:"required,gt=0"`
+	Municipality   string  `json:"municipality,omitempty"`
+	FirstTimeBuyer bool    `json:"firstTimeBuyer"`
+	Rounding       string  `json:"rounding,omitempty"`
+}
+
+// TransferTaxes returns the transfer taxes of the purchase according to the rules of its province.
+func (p Purchase) TransferTaxes() (TransferTaxes, error) {
+	err := validate.Check(p)
+	if err != nil {
+		return TransferTaxes{}, err
+	}
+
+	r, err := Lookup(p.Province)
+	if err != nil {
+		return TransferTaxes{}, err
+	}
+
+	rounding, err := money.ParseRoundingMode(p.Rounding)
+	if err != nil {
+		return TransferTaxes{}, err
+	}
+
+	return r.TransferTaxes(money.FromFloat(p.Price), p.Municipality, p.FirstTimeBuyer, rounding), nil
+}
+
+// bracket is a portion of the price taxed at a percentage rate, up to a limit in dollars.
+type bracket struct {
+	upTo float64
+	rate float64
+}
+
+// taxBrackets returns the tax on the price taxed by brackets, the last bracket has no limit.
+func taxBrackets(price money.Money, brackets []bracket, rounding money.RoundingMode) money.Money {
+	var tax, lower money.Money
+	for _, b := range brackets {
+		upper := price
+		if b.upTo != 0 && money.FromFloat(b.upTo) < price {
+			upper = money.FromFloat(b.upTo)
+		}
+		if upper <= lower {
+			break
+		}
+		tax += (upper - lower).MulRate(b.rate, 1, rounding)
+		lower = upper
+	}
+	return tax
+}
+
+// transferTaxes returns the transfer taxes with their total, the rebate can not exceed the taxes.
+func transferTaxes(provincial, municipal, rebate money.Money) TransferTaxes {
+	if rebate > provincial+municipal {
+		rebate = provincial + municipal
+	}
+	return TransferTaxes{
+		LandTransferTax:          provincial,
+		MunicipalLandTransferTax: municipal,
+		FirstTimeBuyerRebate:     rebate,
+		Total:                    provincial + municipal - rebate,
+	}
+}
@@ -0,0 +1,59 @@
+package province
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	t.Run("should return british columbia when the province is empty", func(t *testing.T) {
+		got, err := Lookup("")
+		tests.AssertNilError(t, err)
+		if _, ok := got.(britishColumbia); !ok {
+			t.Errorf("got %T want britishColumbia", got)
+		}
+	})
+
+	t.Run("should ignore the case of the province", func(t *testing.T) {
+		got, err := Lookup("on")
+		tests.AssertNilError(t, err)
+		if _, ok := got.(ontario); !ok {
+			t.Errorf("got %T want ontario", got)
+		}
+	})
+
+	t.Run("should return an error for an unsupported province", func(t *testing.T) {
+		_, err := Lookup("AB")
+		tests.AssertEqualErrors(t, err, ErrUnsupportedProvince)
+	})
+}
+
+func TestPurchaseTransferTaxes(t *testing.T) {
+	t.Run("should return the transfer taxes of the province", func(t *testing.T) {
+		got, err := Purchase{Province: Ontario, Price: 500000, Municipality: "Toronto"}.TransferTaxes()
+		tests.AssertNilError(t, err)
+		tests.AssertSameMoney(t, got.LandTransferTax, 6475)
+		tests.AssertSameMoney(t, got.MunicipalLandTransferTax, 6475)
+		tests.AssertSameMoney(t, got.Total, 12950)
+	})
+
+	t.Run("should return a validation error without a price", func(t *testing.T) {
+		_, err := Purchase{}.TransferTaxes()
+		fieldError := validate.GetFieldErrors(err)[0]
+		if fieldError.Field != "price" {
+			t.Errorf("expected price got %s", fieldError.Field)
+		}
+	})
+}
+
+func TestTaxBrackets(t *testing.T) {
+	brackets := []bracket{{upTo: 100, rate: 1}, {upTo: 200, rate: 2}, {rate: 3}}
+
+	t.Run("should tax every portion of the price at the rate of its bracket", func(t *testing.T) {
+		tests.AssertSameMoney(t, taxBrackets(money.FromFloat(50), brackets, money.HalfUp), 0.5)
+		tests.AssertSameMoney(t, taxBrackets(money.FromFloat(150), brackets, money.HalfUp), 2)
+		tests.AssertSameMoney(t, taxBrackets(money.FromFloat(300), brackets, money.HalfUp), 6)
+	})
+}
@@ -0,0 +1,23 @@
+package province
+
+import "github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+
+// qcTransferDuties are the Quebec property transfer duties (welcome tax) brackets.
+var qcTransferDuties = []bracket{
+	{upTo: 61_500, rate: 0.5},
+	{upTo: 307_800, rate: 1},
+	{rate: 1.5},
+}
+
+// quebec holds the rules of Quebec.
+type quebec struct{}
+
+// TransferTaxes returns the Quebec property transfer duties, there is no provincial first time buyer rebate.
+func (quebec) TransferTaxes(price money.Money, _ string, _ bool, rounding money.RoundingMode) TransferTaxes {
+	return transferTaxes(taxBrackets(price, qcTransferDuties, rounding), 0, 0)
+}
+
+// PremiumTaxRate returns the Quebec tax charged on insurance premiums.
+func (quebec) PremiumTaxRate() float64 {
+	return 9
+}
@@ -0,0 +1,19 @@
+package province
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"testing"
+)
+
+func TestQuebecTransferTaxes(t *testing.T) {
+	t.Run("should return the property transfer duties", func(t *testing.T) {
+		got := quebec{}.TransferTaxes(money.FromFloat(500000), "Montreal", true, money.HalfUp)
+		tests.AssertSameMoney(t, got.LandTransferTax, 307.5+2463+2883)
+		tests.AssertSameMoney(t, got.FirstTimeBuyerRebate, 0)
+	})
+
+	t.Run("should charge tax on the insurance premium", func(t *testing.T) {
+		tests.AssertSameFloat(t, quebec{}.PremiumTaxRate(), 9)
+	})
+}
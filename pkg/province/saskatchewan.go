@@ -0,0 +1,22 @@
+package province
+
+import "github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+
+// skTitleTransferFee are the Saskatchewan land titles transfer fee brackets, Saskatchewan has no land transfer tax.
+var skTitleTransferFee = []bracket{
+	{upTo: 6_300, rate: 0},
+	{rate: 0.4},
+}
+
+// saskatchewan holds the rules of Saskatchewan.
+type saskatchewan struct{}
+
+// TransferTaxes returns the Saskatchewan title transfer fee as land transfer tax.
+func (saskatchewan) TransferTaxes(price money.Money, _ string, _ bool, rounding money.RoundingMode) TransferTaxes {
+	return transferTaxes(taxBrackets(price, skTitleTransferFee, rounding), 0, 0)
+}
+
+// PremiumTaxRate returns the Saskatchewan provincial sales tax charged on insurance premiums.
+func (saskatchewan) PremiumTaxRate() float64 {
+	return 6
+}
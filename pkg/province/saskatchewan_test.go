@@ -0,0 +1,21 @@
+package province
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"testing"
+)
+
+func TestSaskatchewanTransferTaxes(t *testing.T) {
+	t.Run("should return the title transfer fee", func(t *testing.T) {
+		got := saskatchewan{}.TransferTaxes(money.FromFloat(400000), "", false, money.HalfUp)
+		tests.AssertSameMoney(t, got.LandTransferTax, 1574.8)
+
+		got = saskatchewan{}.TransferTaxes(money.FromFloat(5000), "", false, money.HalfUp)
+		tests.AssertSameMoney(t, got.LandTransferTax, 0)
+	})
+
+	t.Run("should charge tax on the insurance premium", func(t *testing.T) {
+		tests.AssertSameFloat(t, saskatchewan{}.PremiumTaxRate(), 6)
+	})
+}
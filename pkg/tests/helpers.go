@@ -2,6 +2,7 @@ package tests
 
 import (
 	"errors"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
 	"testing"
 )
 
@@ -38,3 +39,12 @@ func AssertEqualErrors(t testing.TB, got, want error) {
 		t.Errorf("Expected %v, got %v", got, want)
 	}
 }
+
+// AssertSameMoney asserts a money amount equals an amount of dollars
+func AssertSameMoney(t testing.TB, got money.Money, want float64) {
+	t.Helper()
+
+	if got != money.FromFloat(want) {
+		t.Errorf("got %s want %.2f", got, want)
+	}
+}
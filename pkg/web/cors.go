@@ -0,0 +1,98 @@
+package web
+
+import (
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORS holds the cross-origin resource sharing policy of the API.
+type CORS struct {
+	// AllowedOrigins are the origins allowed to call the API, either exact origins such as https://example.com or
+	// patterns such as https://*.example.com. A single * allows any origin.
+	AllowedOrigins []string
+
+	// AllowedMethods are the methods allowed in cross-origin requests, POST when not provided.
+	AllowedMethods []string
+
+	// AllowedHeaders are the request headers allowed in cross-origin requests, Content-Type when not provided.
+	AllowedHeaders []string
+
+	// AllowCredentials allows cross-origin requests to include cookies and authorization headers, only from the
+	// origins listed or matching a pattern, never from the ones allowed by the wildcard.
+	AllowCredentials bool
+
+	// MaxAge is how long browsers may cache the result of a preflight request, not sent when zero.
+	MaxAge time.Duration
+}
+
+// Middleware returns a handler adding the CORS headers to the responses of allowed origins before calling the next
+// handler. Preflight requests of allowed origins are answered without calling it.
+func (c CORS) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		w.Header().Add("Vary", "Origin")
+		listed := c.listed(origin)
+		if origin == "" || !listed && !c.anyOrigin() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Credentials are only allowed to the origins listed, any origin allowed by the wildcard gets * without them.
+		switch {
+		case listed && c.AllowCredentials:
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		case c.anyOrigin():
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		default:
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+
+		if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Methods", strings.Join(orDefault(c.AllowedMethods, http.MethodPost), ", "))
+		w.Header().Set("Access-Control-Allow-Headers", strings.Join(orDefault(c.AllowedHeaders, "Content-Type"), ", "))
+		if c.MaxAge > 0 {
+			w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(c.MaxAge.Seconds())))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// listed returns whether the origin matches any of the allowed origins other than the wildcard, ignoring case.
+func (c CORS) listed(origin string) bool {
+	origin = strings.ToLower(origin)
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == "*" {
+			continue
+		}
+		if matched, err := path.Match(strings.ToLower(allowed), origin); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// anyOrigin returns whether every origin is allowed.
+func (c CORS) anyOrigin() bool {
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// orDefault returns the values, or the default ones when there are none.
+func orDefault(values []string, defaults ...string) []string {
+	if len(values) == 0 {
+		return defaults
+	}
+	return values
+}
@@ -0,0 +1,101 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	cors := CORS{
+		AllowedOrigins: []string{"http://localhost:3001", "https://*.example.com"},
+		MaxAge:         10 * time.Minute,
+	}
+
+	serve := func(c CORS, method, origin string, preflight bool) *httptest.ResponseRecorder {
+		request, _ := http.NewRequest(method, "/paymentSchedule", http.NoBody)
+		if origin != "" {
+			request.Header.Set("Origin", origin)
+		}
+		if preflight {
+			request.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		}
+		response := httptest.NewRecorder()
+		c.Middleware(ok).ServeHTTP(response, request)
+		return response
+	}
+
+	assertHeader := func(t *testing.T, response *httptest.ResponseRecorder, header, want string) {
+		t.Helper()
+		if got := response.Header().Get(header); got != want {
+			t.Errorf("%s: got %q, want %q", header, got, want)
+		}
+	}
+
+	t.Run("allows the origins in the list and matching the patterns", func(t *testing.T) {
+		for _, origin := range []string{"http://localhost:3001", "https://staging.example.com", "HTTPS://WWW.EXAMPLE.COM"} {
+			response := serve(cors, http.MethodPost, origin, false)
+			assertHeader(t, response, "Access-Control-Allow-Origin", origin)
+			assertHeader(t, response, "Vary", "Origin")
+		}
+	})
+
+	t.Run("does not add headers for other origins", func(t *testing.T) {
+		for _, origin := range []string{"http://localhost:3000", "https://example.com", "https://evil.com/.example.com", ""} {
+			response := serve(cors, http.MethodPost, origin, false)
+			assertHeader(t, response, "Access-Control-Allow-Origin", "")
+			if response.Code != http.StatusOK {
+				t.Errorf("got %v, want %v", response.Code, http.StatusOK)
+			}
+		}
+	})
+
+	t.Run("answers preflight requests with the allowed methods, headers and max age", func(t *testing.T) {
+		response := serve(cors, http.MethodOptions, "http://localhost:3001", true)
+		if response.Code != http.StatusNoContent {
+			t.Errorf("got %v, want %v", response.Code, http.StatusNoContent)
+		}
+		assertHeader(t, response, "Access-Control-Allow-Methods", "POST")
+		assertHeader(t, response, "Access-Control-Allow-Headers", "Content-Type")
+		assertHeader(t, response, "Access-Control-Max-Age", "600")
+		assertHeader(t, response, "Access-Control-Allow-Credentials", "")
+	})
+
+	t.Run("answers preflight requests with the methods and headers provided", func(t *testing.T) {
+		custom := cors
+		custom.AllowedMethods = []string{http.MethodGet, http.MethodPost}
+		custom.AllowedHeaders = []string{"Content-Type", "Authorization"}
+		custom.AllowCredentials = true
+		response := serve(custom, http.MethodOptions, "https://app.example.com", true)
+		assertHeader(t, response, "Access-Control-Allow-Methods", "GET, POST")
+		assertHeader(t, response, "Access-Control-Allow-Headers", "Content-Type, Authorization")
+		assertHeader(t, response, "Access-Control-Allow-Credentials", "true")
+	})
+
+	t.Run("passes options requests that are not preflights to the next handler", func(t *testing.T) {
+		response := serve(cors, http.MethodOptions, "http://localhost:3001", false)
+		if response.Code != http.StatusOK {
+			t.Errorf("got %v, want %v", response.Code, http.StatusOK)
+		}
+	})
+
+	t.Run("allows any origin with a wildcard", func(t *testing.T) {
+		wildcard := CORS{AllowedOrigins: []string{"*"}}
+		assertHeader(t, serve(wildcard, http.MethodPost, "https://any.com", false), "Access-Control-Allow-Origin", "*")
+
+	})
+
+	t.Run("allows credentials only to the origins listed along a wildcard", func(t *testing.T) {
+		wildcard := CORS{AllowedOrigins: []string{"*", "https://app.example.com"}, AllowCredentials: true}
+
+		response := serve(wildcard, http.MethodPost, "https://evil.example", false)
+		assertHeader(t, response, "Access-Control-Allow-Origin", "*")
+		assertHeader(t, response, "Access-Control-Allow-Credentials", "")
+
+		response = serve(wildcard, http.MethodPost, "https://app.example.com", false)
+		assertHeader(t, response, "Access-Control-Allow-Origin", "https://app.example.com")
+		assertHeader(t, response, "Access-Control-Allow-Credentials", "true")
+	})
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/web"
+	"log"
+	"net/http"
+)
+
+type compareResponse struct {
+	Baseline  int                       `json:"baseline"`
+	Scenarios []mortgage.ScenarioResult `json:"scenarios"`
+}
+
+func CompareHandler(w http.ResponseWriter, r *http.Request) {
+	var comparison mortgage.Comparison
+	if err := web.Decode(r, &comparison); err != nil {
+		log.Println("unable to decode payload: %w", err)
+		internalServerErrorResponse(w)
+		return
+	}
+
+	scenarios, err := comparison.Compare()
+	if err != nil {
+		calculationErrorResponse(w, err)
+		return
+	}
+	resp := compareResponse{Baseline: comparison.Baseline, Scenarios: scenarios}
+
+	web.Respond(w, resp, http.StatusOK)
+}
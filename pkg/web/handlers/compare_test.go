@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCompareHandler(t *testing.T) {
+	fixed := mortgage.Calculator{
+		PropertyPrice:      500000,
+		DownPayment:        100000,
+		AnnualInterestRate: 5,
+		AmortizationPeriod: 25,
+		Schedule:           mortgage.Monthly,
+	}
+	variable := fixed
+	variable.AnnualInterestRate = 4.5
+	c := mortgage.Comparison{Scenarios: []mortgage.Calculator{fixed, variable}, Baseline: 1}
+
+	t.Run("returns every scenario compared against the baseline", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&c)
+		request, _ := http.NewRequest(http.MethodPost, "/compare", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		CompareHandler(response, request)
+		resp := compareResponse{}
+		json.NewDecoder(response.Body).Decode(&resp)
+		tests.AssertSameInt(t, resp.Baseline, 1)
+		tests.AssertSameInt(t, len(resp.Scenarios), 2)
+		tests.AssertSameMoney(t, resp.Scenarios[0].PaymentPerSchedule, 2338.36)
+		tests.AssertSameMoney(t, resp.Scenarios[1].PaymentDelta, 0)
+		if resp.Scenarios[0].PaymentDelta <= 0 {
+			t.Errorf("got %s, want a positive payment delta", resp.Scenarios[0].PaymentDelta)
+		}
+	})
+
+	t.Run("returns a baseline out of range error response", func(t *testing.T) {
+		invalid := c
+		invalid.Baseline = 5
+		jsonBody, _ := json.Marshal(&invalid)
+		request, _ := http.NewRequest(http.MethodPost, "/compare", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		CompareHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		want := mortgage.ErrBaselineOutOfRange.Error()
+		if err.Error != want {
+			t.Errorf("got %s, want %s", err.Error, want)
+		}
+	})
+
+	t.Run("returns a bad request naming the scenario that failed", func(t *testing.T) {
+		invalid := mortgage.Comparison{Scenarios: []mortgage.Calculator{fixed, variable}}
+		invalid.Scenarios[1].DownPayment = 1000
+		jsonBody, _ := json.Marshal(&invalid)
+		request, _ := http.NewRequest(http.MethodPost, "/compare", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		CompareHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		want := "scenario 1: " + mortgage.ErrDownPaymentNotLargeEnough.Error()
+		if err.Error != want {
+			t.Errorf("got %s, want %s", err.Error, want)
+		}
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("got %v, want %v", response.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("returns a bad request with the field errors of the scenario that failed", func(t *testing.T) {
+		invalid := mortgage.Comparison{Scenarios: []mortgage.Calculator{fixed, variable}}
+		invalid.Scenarios[1].Schedule = ""
+		jsonBody, _ := json.Marshal(&invalid)
+		request, _ := http.NewRequest(http.MethodPost, "/compare", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		CompareHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		if len(err.Fields) == 0 || err.Fields[0].Field != "schedule" {
+			t.Errorf("got %v, want a schedule field error", err.Fields)
+		}
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("got %v, want %v", response.Code, http.StatusBadRequest)
+		}
+	})
+}
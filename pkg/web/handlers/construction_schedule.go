@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/web"
+	"log"
+	"net/http"
+)
+
+func ConstructionScheduleHandler(w http.ResponseWriter, r *http.Request) {
+	var construction mortgage.Construction
+	if err := web.Decode(r, &construction); err != nil {
+		log.Println("unable to decode payload: %w", err)
+		internalServerErrorResponse(w)
+		return
+	}
+
+	schedule, err := construction.DrawSchedule()
+	if err != nil {
+		calculationErrorResponse(w, err)
+		return
+	}
+
+	web.Respond(w, schedule, http.StatusOK)
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/web"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestConstructionScheduleHandler(t *testing.T) {
+	c := mortgage.Construction{
+		Calculator: mortgage.Calculator{
+			PropertyPrice:      500000,
+			DownPayment:        100000,
+			AnnualInterestRate: 6,
+			AmortizationPeriod: 25,
+			Schedule:           mortgage.Monthly,
+		},
+		Draws: []mortgage.Draw{{Period: 1, Amount: 100000}, {Period: 4, Amount: 150000}, {Period: 6, Amount: 150000}},
+	}
+
+	t.Run("returns the interest only phase and the amortizing payment", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&c)
+		request, _ := http.NewRequest(http.MethodPost, "/constructionSchedule", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		ConstructionScheduleHandler(response, request)
+		schedule := mortgage.ConstructionSchedule{}
+		json.NewDecoder(response.Body).Decode(&schedule)
+		tests.AssertSameInt(t, len(schedule.InterestOnly), 6)
+		tests.AssertSameMoney(t, schedule.TotalInterestOnly, 6000)
+		tests.AssertSameMoney(t, schedule.PaymentPerSchedule, 2577.21)
+	})
+
+	t.Run("returns a draws do not match error response", func(t *testing.T) {
+		invalid := c
+		invalid.Draws = []mortgage.Draw{{Period: 1, Amount: 100000}}
+		jsonBody, _ := json.Marshal(&invalid)
+		request, _ := http.NewRequest(http.MethodPost, "/constructionSchedule", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		ConstructionScheduleHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		want := mortgage.ErrDrawsDoNotMatchMortgage.Error()
+		if err.Error != want {
+			t.Errorf("got %s, want %s", err.Error, want)
+		}
+	})
+
+	t.Run("returns method not allowed if the http method is not supported", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/constructionSchedule", http.NoBody)
+		response := httptest.NewRecorder()
+		API(web.CORS{}).ServeHTTP(response, request)
+		if response.Code != http.StatusMethodNotAllowed {
+			t.Errorf("got %v, want %v", response.Code, http.StatusMethodNotAllowed)
+		}
+	})
+}
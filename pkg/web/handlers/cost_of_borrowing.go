@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/web"
+	"log"
+	"net/http"
+)
+
+func CostOfBorrowingHandler(w http.ResponseWriter, r *http.Request) {
+	var disclosure mortgage.Disclosure
+	if err := web.Decode(r, &disclosure); err != nil {
+		log.Println("unable to decode payload: %w", err)
+		internalServerErrorResponse(w)
+		return
+	}
+
+	costOfBorrowing, err := disclosure.CostOfBorrowing()
+	if err != nil {
+		calculationErrorResponse(w, err)
+		return
+	}
+
+	web.Respond(w, costOfBorrowing, http.StatusOK)
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCostOfBorrowingHandler(t *testing.T) {
+	d := mortgage.Disclosure{
+		Calculator: mortgage.Calculator{
+			PropertyPrice:      500000,
+			DownPayment:        50000,
+			AnnualInterestRate: 5,
+			AmortizationPeriod: 25,
+			Schedule:           mortgage.Monthly,
+		},
+		LenderFees: 1000,
+		BrokerFees: 2000,
+	}
+
+	t.Run("returns the payment, effective annual rate and annual percentage rate", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&d)
+		request, _ := http.NewRequest(http.MethodPost, "/costOfBorrowing", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		CostOfBorrowingHandler(response, request)
+		costOfBorrowing := mortgage.CostOfBorrowing{}
+		json.NewDecoder(response.Body).Decode(&costOfBorrowing)
+		tests.AssertSameMoney(t, costOfBorrowing.PaymentPerSchedule, 2712.21)
+		tests.AssertSameFloat(t, costOfBorrowing.EffectiveAnnualRate, 5.12)
+		tests.AssertSameFloat(t, costOfBorrowing.AnnualPercentageRate, 5.89)
+	})
+
+	t.Run("returns a term out of range error response", func(t *testing.T) {
+		invalid := d
+		invalid.Term = 30
+		jsonBody, _ := json.Marshal(&invalid)
+		request, _ := http.NewRequest(http.MethodPost, "/costOfBorrowing", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		CostOfBorrowingHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		want := mortgage.ErrTermOutOfRange.Error()
+		if err.Error != want {
+			t.Errorf("got %s, want %s", err.Error, want)
+		}
+	})
+
+	t.Run("returns field errors if the fees are negative", func(t *testing.T) {
+		invalid := d
+		invalid.LenderFees = -1
+		jsonBody, _ := json.Marshal(&invalid)
+		request, _ := http.NewRequest(http.MethodPost, "/costOfBorrowing", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		CostOfBorrowingHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		if len(err.Fields) == 0 || err.Fields[0].Field != "lenderFees" {
+			t.Errorf("got %v, want a lenderFees field error", err)
+		}
+	})
+}
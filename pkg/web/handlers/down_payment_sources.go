@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/web"
+	"log"
+	"net/http"
+)
+
+func DownPaymentSourcesHandler(w http.ResponseWriter, r *http.Request) {
+	var purchase mortgage.FundedPurchase
+	if err := web.Decode(r, &purchase); err != nil {
+		log.Println("unable to decode payload: %w", err)
+		internalServerErrorResponse(w)
+		return
+	}
+
+	summary, err := purchase.Summary()
+	if err != nil {
+		calculationErrorResponse(w, err)
+		return
+	}
+
+	web.Respond(w, summary, http.StatusOK)
+}
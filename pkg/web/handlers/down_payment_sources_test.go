@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDownPaymentSourcesHandler(t *testing.T) {
+	f := mortgage.FundedPurchase{
+		Calculator: mortgage.Calculator{
+			PropertyPrice:      500000,
+			AnnualInterestRate: 5,
+			AmortizationPeriod: 25,
+			Schedule:           mortgage.Monthly,
+		},
+		Sources: mortgage.DownPaymentSources{
+			Savings:         30000,
+			FHSA:            40000,
+			RRSP:            30000,
+			WithdrawalYear:  2024,
+			MarginalTaxRate: 30,
+		},
+	}
+
+	t.Run("returns the payment and the home buyers' plan repayments", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&f)
+		request, _ := http.NewRequest(http.MethodPost, "/downPaymentSources", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		DownPaymentSourcesHandler(response, request)
+		resp := mortgage.FundedPurchaseSummary{}
+		json.NewDecoder(response.Body).Decode(&resp)
+		tests.AssertSameMoney(t, resp.PaymentPerSchedule, 2338.36)
+		tests.AssertSameInt(t, len(resp.HomeBuyersPlanRepayments), 15)
+		tests.AssertSameMoney(t, resp.FHSATaxBenefit, 12000)
+	})
+
+	t.Run("returns a bad request when the home buyers' plan limit is exceeded", func(t *testing.T) {
+		invalid := f
+		invalid.Sources.Savings = 0
+		invalid.Sources.RRSP = 60000.01
+		jsonBody, _ := json.Marshal(&invalid)
+		request, _ := http.NewRequest(http.MethodPost, "/downPaymentSources", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		DownPaymentSourcesHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		want := mortgage.ErrHomeBuyersPlanLimitExceeded.Error()
+		if err.Error != want {
+			t.Errorf("got %s, want %s", err.Error, want)
+		}
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("got %v, want %v", response.Code, http.StatusBadRequest)
+		}
+	})
+}
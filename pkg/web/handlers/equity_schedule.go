@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/web"
+	"log"
+	"net/http"
+)
+
+func EquityScheduleHandler(w http.ResponseWriter, r *http.Request) {
+	var equity mortgage.Equity
+	if err := web.Decode(r, &equity); err != nil {
+		log.Println("unable to decode payload: %w", err)
+		internalServerErrorResponse(w)
+		return
+	}
+
+	schedule, err := equity.EquitySchedule()
+	if err != nil {
+		calculationErrorResponse(w, err)
+		return
+	}
+
+	web.Respond(w, schedule, http.StatusOK)
+}
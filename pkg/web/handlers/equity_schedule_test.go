@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEquityScheduleHandler(t *testing.T) {
+	e := mortgage.Equity{
+		Calculator: mortgage.Calculator{
+			PropertyPrice:      500000,
+			DownPayment:        50000,
+			AnnualInterestRate: 5,
+			AmortizationPeriod: 25,
+			Schedule:           mortgage.Monthly,
+		},
+		StartDate:        "2024-01-15",
+		AppreciationRate: 3,
+	}
+
+	t.Run("returns the equity after every payment", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&e)
+		request, _ := http.NewRequest(http.MethodPost, "/equitySchedule", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		EquityScheduleHandler(response, request)
+		resp := mortgage.EquitySchedule{}
+		json.NewDecoder(response.Body).Decode(&resp)
+		tests.AssertSameInt(t, len(resp.Periods), 300)
+		tests.AssertSameMoney(t, resp.Periods[11].PropertyValue, 515000)
+		if resp.LoanToValueUnder80Date == "" {
+			t.Errorf("got no date under 80%%")
+		}
+	})
+
+	t.Run("returns a validation error without a start date", func(t *testing.T) {
+		invalid := e
+		invalid.StartDate = ""
+		jsonBody, _ := json.Marshal(&invalid)
+		request, _ := http.NewRequest(http.MethodPost, "/equitySchedule", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		EquityScheduleHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		if err.Fields[0].Field != "startDate" {
+			t.Errorf("got %v, want startDate", err.Fields)
+		}
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("got %v, want %v", response.Code, http.StatusBadRequest)
+		}
+	})
+}
@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/web"
+	"log"
+	"net/http"
+)
+
+func ExtraPaymentHandler(w http.ResponseWriter, r *http.Request) {
+	var extraPayment mortgage.ExtraPayment
+	if err := web.Decode(r, &extraPayment); err != nil {
+		log.Println("unable to decode payload: %w", err)
+		internalServerErrorResponse(w)
+		return
+	}
+
+	plan, err := extraPayment.Solve()
+	if err != nil {
+		calculationErrorResponse(w, err)
+		return
+	}
+
+	web.Respond(w, plan, http.StatusOK)
+}
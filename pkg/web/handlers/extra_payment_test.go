@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExtraPaymentHandler(t *testing.T) {
+	e := mortgage.ExtraPayment{
+		Calculator: mortgage.Calculator{
+			PropertyPrice:      500000,
+			DownPayment:        100000,
+			AnnualInterestRate: 5,
+			AmortizationPeriod: 25,
+			Schedule:           mortgage.Monthly,
+		},
+		StartDate:  "2024-01-01",
+		TargetDate: "2044-01-01",
+		Method:     mortgage.PerPayment,
+	}
+
+	t.Run("returns the extra payment needed", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&e)
+		request, _ := http.NewRequest(http.MethodPost, "/extraPayment", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		ExtraPaymentHandler(response, request)
+		resp := mortgage.ExtraPaymentPlan{}
+		json.NewDecoder(response.Body).Decode(&resp)
+		if resp.Extra <= 0 || resp.PayoffDate > e.TargetDate {
+			t.Errorf("got %v extra paying off on %s, want a payoff by %s", resp.Extra, resp.PayoffDate, e.TargetDate)
+		}
+	})
+
+	t.Run("returns a bad request when the privileges are exceeded", func(t *testing.T) {
+		invalid := e
+		invalid.TargetDate = "2034-01-01"
+		jsonBody, _ := json.Marshal(&invalid)
+		request, _ := http.NewRequest(http.MethodPost, "/extraPayment", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		ExtraPaymentHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		want := mortgage.ErrPrepaymentPrivilegeExceeded.Error()
+		if err.Error != want {
+			t.Errorf("got %s, want %s", err.Error, want)
+		}
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("got %v, want %v", response.Code, http.StatusBadRequest)
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/propertytax"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/province"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/web"
+	"log"
+	"net/http"
+)
+
+// WarnLog logs the requests rejected for the values sent by the client, the errors of the requests that could not be
+// served are always logged by the standard logger.
+var WarnLog = log.Default()
+
+// badRequestErrors are the calculation errors caused by the values sent by the client.
+var badRequestErrors = []error{
+	mortgage.ErrDownPaymentNotLargeEnough,
+	mortgage.ErrPeriodOutOfRange,
+	mortgage.ErrPeriodNotAMultipleOfFive,
+	mortgage.ErrTermOutOfRange,
+	mortgage.ErrTrancheAmortizationOutOfRange,
+	mortgage.ErrTermLongerThanAmortization,
+	mortgage.ErrInvalidRateType,
+	mortgage.ErrLoanToValueExceeded,
+	mortgage.ErrHelocLimitExceeded,
+	mortgage.ErrDrawsDoNotMatchMortgage,
+	mortgage.ErrInterestOnlyShorterThanDraws,
+	mortgage.ErrInterestOnlyLongerThanPeriod,
+	mortgage.ErrBaselineOutOfRange,
+	mortgage.ErrInvalidAxisField,
+	mortgage.ErrInvalidAxisRange,
+	mortgage.ErrAxisNotWholeNumbers,
+	mortgage.ErrDuplicatedAxisField,
+	mortgage.ErrGridTooLarge,
+	mortgage.ErrInvalidExtraPaymentMethod,
+	mortgage.ErrTargetDateOutOfRange,
+	mortgage.ErrPrepaymentPrivilegeExceeded,
+	mortgage.ErrSavingsGoalUnreachable,
+	mortgage.ErrHomeBuyersPlanLimitExceeded,
+	mortgage.ErrDownPaymentSourcesMismatch,
+	mortgage.ErrInvestmentDownPaymentTooLow,
+	mortgage.ErrInvalidRentalTreatment,
+	mortgage.ErrLoanAmountTooSmall,
+	mortgage.ErrFeesExceedAdvance,
+	propertytax.ErrUnknownMunicipality,
+	propertytax.ErrInvalidResidency,
+	province.ErrUnsupportedProvince,
+	money.ErrInvalidRoundingMode,
+	money.ErrAmountOutOfRange,
+}
+
+type errorResponse struct {
+	Error  string               `json:"error"`
+	Fields validate.FieldErrors `json:"fields,omitempty"`
+}
+
+func notFoundResponse(w http.ResponseWriter) {
+	resp := errorResponse{Error: http.StatusText(http.StatusNotFound)}
+	web.Respond(w, resp, http.StatusNotFound)
+}
+
+func internalServerErrorResponse(w http.ResponseWriter) {
+	resp := errorResponse{Error: http.StatusText(http.StatusInternalServerError)}
+	web.Respond(w, resp, http.StatusInternalServerError)
+}
+
+func methodNotAllowedResponse(w http.ResponseWriter) {
+	resp := errorResponse{Error: http.StatusText(http.StatusMethodNotAllowed)}
+	web.Respond(w, resp, http.StatusMethodNotAllowed)
+}
+
+// calculationErrorResponse responds with a bad request when the error, or any error it wraps, was caused by the request
+// values, otherwise responds with an internal server error.
+func calculationErrorResponse(w http.ResponseWriter, err error) {
+	if fieldErrors := validate.GetFieldErrors(err); fieldErrors != nil {
+		WarnLog.Println("data validation error: ", err)
+		resp := errorResponse{Error: "data validation error", Fields: fieldErrors}
+		web.Respond(w, resp, http.StatusBadRequest)
+		return
+	}
+
+	for _, badRequestErr := range badRequestErrors {
+		if errors.Is(err, badRequestErr) {
+			WarnLog.Println("error calculating mortgage: ", err)
+			resp := errorResponse{Error: err.Error()}
+			web.Respond(w, resp, http.StatusBadRequest)
+			return
+		}
+	}
+	log.Println("error: ", err.Error())
+	internalServerErrorResponse(w)
+}
+
+// NotFoundHandler responds with a not found error to the paths not served by other handlers.
+func NotFoundHandler(w http.ResponseWriter, r *http.Request) {
+	notFoundResponse(w)
+}
+
+// routes are the calculation endpoints, served under /v1 and, for the clients built before versioning, without prefix.
+var routes = map[string]http.HandlerFunc{
+	"/paymentSchedule":       PaymentScheduleHandler,
+	"/portSchedule":          PortScheduleHandler,
+	"/splitSchedule":         SplitScheduleHandler,
+	"/readvanceableSchedule": ReadvanceableScheduleHandler,
+	"/constructionSchedule":  ConstructionScheduleHandler,
+	"/costOfBorrowing":       CostOfBorrowingHandler,
+	"/compare":               CompareHandler,
+	"/sensitivityGrid":       SensitivityGridHandler,
+	"/rateSimulation":        RateSimulationHandler,
+	"/rentVsBuy":             RentVsBuyHandler,
+	"/ownershipCost":         OwnershipCostHandler,
+	"/equitySchedule":        EquityScheduleHandler,
+	"/extraPayment":          ExtraPaymentHandler,
+	"/savingsPlan":           SavingsPlanHandler,
+	"/downPaymentSources":    DownPaymentSourcesHandler,
+	"/transferTaxes":         TransferTaxesHandler,
+	"/investmentProperty":    InvestmentPropertyHandler,
+	"/qualification":         QualificationHandler,
+	"/propertyTax":           PropertyTaxHandler,
+	"/vacancyTaxes":          VacancyTaxesHandler,
+}
+
+// API returns the handler serving every endpoint of the calculator with the CORS policy.
+func API(cors web.CORS) http.Handler {
+	router := web.NewRouter()
+	router.NotFound = NotFoundHandler
+	router.MethodNotAllowed = func(w http.ResponseWriter, r *http.Request) { methodNotAllowedResponse(w) }
+
+	v1 := router.Group("/v1")
+	for path, handler := range routes {
+		v1.Handle(http.MethodPost, path, handler)
+		router.Handle(http.MethodPost, path, handler)
+	}
+	router.Handle(http.MethodPost, "/v2/paymentSchedule", PaymentScheduleV2Handler)
+
+	return cors.Middleware(router)
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInvestmentPropertyHandler(t *testing.T) {
+	i := mortgage.Investment{
+		Calculator: mortgage.Calculator{
+			PropertyPrice:      500000,
+			DownPayment:        100000,
+			AnnualInterestRate: 5,
+			AmortizationPeriod: 25,
+			Schedule:           mortgage.Monthly,
+		},
+		MonthlyRent: 3000,
+		PropertyTax: 2000,
+	}
+
+	t.Run("returns the cash flow analysis of the property", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&i)
+		request, _ := http.NewRequest(http.MethodPost, "/investmentProperty", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		InvestmentPropertyHandler(response, request)
+		resp := mortgage.InvestmentAnalysis{}
+		json.NewDecoder(response.Body).Decode(&resp)
+		tests.AssertSameMoney(t, resp.NetOperatingIncome, 34000)
+		tests.AssertSameFloat(t, resp.CapRate, 6.8)
+	})
+
+	t.Run("returns a bad request when the down payment is lower than 20%", func(t *testing.T) {
+		invalid := i
+		invalid.DownPayment = 50000
+		jsonBody, _ := json.Marshal(&invalid)
+		request, _ := http.NewRequest(http.MethodPost, "/investmentProperty", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		InvestmentPropertyHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		want := mortgage.ErrInvestmentDownPaymentTooLow.Error()
+		if err.Error != want {
+			t.Errorf("got %s, want %s", err.Error, want)
+		}
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("got %v, want %v", response.Code, http.StatusBadRequest)
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOwnershipCostHandler(t *testing.T) {
+	o := mortgage.Ownership{
+		Calculator: mortgage.Calculator{
+			PropertyPrice:      500000,
+			DownPayment:        100000,
+			AnnualInterestRate: 5,
+			AmortizationPeriod: 25,
+			Schedule:           mortgage.Monthly,
+		},
+		Years:       5,
+		PropertyTax: 2000,
+		StrataFees:  300,
+	}
+
+	t.Run("returns the yearly cost of ownership", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&o)
+		request, _ := http.NewRequest(http.MethodPost, "/ownershipCost", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		OwnershipCostHandler(response, request)
+		resp := mortgage.OwnershipProjection{}
+		json.NewDecoder(response.Body).Decode(&resp)
+		tests.AssertSameInt(t, len(resp.Years), 5)
+		tests.AssertSameMoney(t, resp.Years[0].TotalCost, 2338.36*12+2000+3600)
+	})
+
+	t.Run("returns a validation error without years", func(t *testing.T) {
+		invalid := o
+		invalid.Years = 0
+		jsonBody, _ := json.Marshal(&invalid)
+		request, _ := http.NewRequest(http.MethodPost, "/ownershipCost", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		OwnershipCostHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		if err.Fields[0].Field != "years" {
+			t.Errorf("got %v, want years", err.Fields)
+		}
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("got %v, want %v", response.Code, http.StatusBadRequest)
+		}
+	})
+}
@@ -3,9 +3,11 @@ package handlers
 import (
 	"bytes"
 	"encoding/json"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
 	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
 	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
 	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/validate"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/web"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -29,7 +31,7 @@ func TestPaymentScheduleHandler(t *testing.T) {
 		paymentSchedule := paymentScheduleResponse{}
 		json.NewDecoder(response.Body).Decode(&paymentSchedule)
 		want := 1832.51
-		tests.AssertSameFloat(t, paymentSchedule.PaymentPerSchedule, want)
+		tests.AssertSameMoney(t, paymentSchedule.PaymentPerSchedule, want)
 	})
 
 	t.Run("returns the correct values for a biweekly schedule", func(t *testing.T) {
@@ -41,7 +43,7 @@ func TestPaymentScheduleHandler(t *testing.T) {
 		paymentSchedule := paymentScheduleResponse{}
 		json.NewDecoder(response.Body).Decode(&paymentSchedule)
 		want := 845.05
-		tests.AssertSameFloat(t, paymentSchedule.PaymentPerSchedule, want)
+		tests.AssertSameMoney(t, paymentSchedule.PaymentPerSchedule, want)
 	})
 
 	t.Run("returns the correct values for a accelerated biweekly schedule", func(t *testing.T) {
@@ -52,15 +54,25 @@ func TestPaymentScheduleHandler(t *testing.T) {
 		PaymentScheduleHandler(response, request)
 		paymentSchedule := paymentScheduleResponse{}
 		json.NewDecoder(response.Body).Decode(&paymentSchedule)
-		want := 916.255
-		tests.AssertSameFloat(t, paymentSchedule.PaymentPerSchedule, want)
+		want := 916.26
+		tests.AssertSameMoney(t, paymentSchedule.PaymentPerSchedule, want)
+	})
+
+	t.Run("returns the payment per schedule under the v1 prefix", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&c)
+		request, _ := http.NewRequest(http.MethodPost, "/v1/paymentSchedule", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		API(web.CORS{}).ServeHTTP(response, request)
+		paymentSchedule := paymentScheduleResponse{}
+		json.NewDecoder(response.Body).Decode(&paymentSchedule)
+		tests.AssertSameMoney(t, paymentSchedule.PaymentPerSchedule, 916.26)
 	})
 
 	t.Run("returns not found if the path is not supported", func(t *testing.T) {
 		jsonBody, _ := json.Marshal(&c)
 		request, _ := http.NewRequest(http.MethodPost, "/test", bytes.NewBuffer(jsonBody))
 		response := httptest.NewRecorder()
-		PaymentScheduleHandler(response, request)
+		API(web.CORS{}).ServeHTTP(response, request)
 		err := errorResponse{}
 		json.NewDecoder(response.Body).Decode(&err)
 		want := http.StatusText(http.StatusNotFound)
@@ -69,17 +81,20 @@ func TestPaymentScheduleHandler(t *testing.T) {
 		}
 	})
 
-	t.Run("returns not found if the http method is not supported", func(t *testing.T) {
+	t.Run("returns method not allowed if the http method is not supported", func(t *testing.T) {
 		jsonBody, _ := json.Marshal(&c)
 		request, _ := http.NewRequest(http.MethodGet, "/paymentSchedule", bytes.NewBuffer(jsonBody))
 		response := httptest.NewRecorder()
-		PaymentScheduleHandler(response, request)
+		API(web.CORS{}).ServeHTTP(response, request)
 		err := errorResponse{}
 		json.NewDecoder(response.Body).Decode(&err)
-		want := http.StatusText(http.StatusNotFound)
+		want := http.StatusText(http.StatusMethodNotAllowed)
 		if err.Error != want {
 			t.Errorf("got %s, want %s", err.Error, want)
 		}
+		if got, want := response.Header().Get("Allow"), "OPTIONS, POST"; got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
 	})
 
 	t.Run("returns internal server error if request body is not present", func(t *testing.T) {
@@ -112,13 +127,28 @@ func TestPaymentScheduleHandler(t *testing.T) {
 		}
 	})
 
-	t.Run("when a preflight options request should respond ok status", func(t *testing.T) {
+	t.Run("when a preflight options request should respond no content status", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodOptions, "/paymentSchedule", http.NoBody)
+		request.Header.Set("Origin", "http://localhost:3001")
+		request.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		response := httptest.NewRecorder()
+		API(web.CORS{AllowedOrigins: []string{"http://localhost:3001"}}).ServeHTTP(response, request)
+		if response.Code != http.StatusNoContent {
+			t.Errorf("got %v, want %v", response.Code, http.StatusNoContent)
+		}
+		if got, want := response.Header().Get("Access-Control-Allow-Origin"), "http://localhost:3001"; got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("adds the allowed origin to the responses of the allowed origins", func(t *testing.T) {
 		jsonBody, _ := json.Marshal(&c)
-		request, _ := http.NewRequest(http.MethodOptions, "/paymentSchedule", bytes.NewBuffer(jsonBody))
+		request, _ := http.NewRequest(http.MethodPost, "/v1/paymentSchedule", bytes.NewBuffer(jsonBody))
+		request.Header.Set("Origin", "https://app.example.com")
 		response := httptest.NewRecorder()
-		PaymentScheduleHandler(response, request)
-		if response.Code != http.StatusOK {
-			t.Errorf("got %v, want %v", response.Code, http.StatusOK)
+		API(web.CORS{AllowedOrigins: []string{"https://*.example.com"}}).ServeHTTP(response, request)
+		if got, want := response.Header().Get("Access-Control-Allow-Origin"), "https://app.example.com"; got != want {
+			t.Errorf("got %s, want %s", got, want)
 		}
 	})
 
@@ -135,6 +165,12 @@ func TestPaymentScheduleHandler(t *testing.T) {
 			c.AmortizationPeriod = 6
 			AssertHandledErrors(t, c, mortgage.ErrPeriodNotAMultipleOfFive)
 		})
+		t.Run("returns an amount out of range error response if the price exceeds the supported amounts", func(t *testing.T) {
+			c.AmortizationPeriod = 25
+			c.PropertyPrice = 1e20
+			c.DownPayment = 2e19
+			AssertHandledErrors(t, c, money.ErrAmountOutOfRange)
+		})
 	})
 }
 
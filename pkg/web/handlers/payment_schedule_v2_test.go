@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/web"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPaymentScheduleV2Handler(t *testing.T) {
+	c := mortgage.Calculator{
+		PropertyPrice:      100000,
+		DownPayment:        5000,
+		AnnualInterestRate: 4.29,
+		AmortizationPeriod: 5,
+		Schedule:           mortgage.Monthly,
+	}
+
+	t.Run("returns the payment along with the cost of borrowing summary", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&c)
+		request, _ := http.NewRequest(http.MethodPost, "/v2/paymentSchedule", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		PaymentScheduleV2Handler(response, request)
+		summary := mortgage.Summary{}
+		json.NewDecoder(response.Body).Decode(&summary)
+		tests.AssertSameMoney(t, summary.PaymentPerSchedule, 1832.51)
+		tests.AssertSameMoney(t, summary.Principal, 98800)
+		tests.AssertSameMoney(t, summary.CMHCPremium, 3800)
+		tests.AssertSameFloat(t, summary.CMHCRate, 4)
+		tests.AssertSameInt(t, summary.NumberOfPayments, 60)
+		tests.AssertSameMoney(t, summary.TotalInterest, 11150.68)
+		tests.AssertSameMoney(t, summary.TotalPaid, 109950.68)
+	})
+
+	t.Run("returns the sales tax on the CMHC premium as cash to close", func(t *testing.T) {
+		ontario := c
+		ontario.Province = "ON"
+		jsonBody, _ := json.Marshal(&ontario)
+		request, _ := http.NewRequest(http.MethodPost, "/v2/paymentSchedule", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		PaymentScheduleV2Handler(response, request)
+		summary := mortgage.Summary{}
+		json.NewDecoder(response.Body).Decode(&summary)
+		tests.AssertSameMoney(t, summary.Principal, 98800)
+		tests.AssertSameMoney(t, summary.CMHCPremiumTax, 304)
+		tests.AssertSameMoney(t, summary.CashToClose.Total, 5000+304+summary.CashToClose.TransferTaxes.Total.Float64())
+	})
+
+	t.Run("keeps the original payment schedule response unchanged", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&c)
+		request, _ := http.NewRequest(http.MethodPost, "/paymentSchedule", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		PaymentScheduleHandler(response, request)
+		var body map[string]any
+		json.NewDecoder(response.Body).Decode(&body)
+		if len(body) != 1 || body["paymentPerSchedule"] != 1832.51 {
+			t.Errorf("got %v, want only the paymentPerSchedule", body)
+		}
+	})
+
+	t.Run("returns not found if the path is not supported", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPost, "/v2/test", http.NoBody)
+		response := httptest.NewRecorder()
+		API(web.CORS{}).ServeHTTP(response, request)
+		if response.Code != http.StatusNotFound {
+			t.Errorf("got %v, want %v", response.Code, http.StatusNotFound)
+		}
+	})
+
+	t.Run("returns a period out of range error response", func(t *testing.T) {
+		invalid := c
+		invalid.AmortizationPeriod = 90
+		jsonBody, _ := json.Marshal(&invalid)
+		request, _ := http.NewRequest(http.MethodPost, "/v2/paymentSchedule", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		PaymentScheduleV2Handler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		want := mortgage.ErrPeriodOutOfRange.Error()
+		if err.Error != want {
+			t.Errorf("got %s, want %s", err.Error, want)
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/money"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/web"
+	"log"
+	"net/http"
+)
+
+type portScheduleResponse struct {
+	PaymentPerSchedule money.Money       `json:"paymentPerSchedule"`
+	BlendedRate        float64           `json:"blendedRate"`
+	Schedule           []mortgage.Period `json:"schedule"`
+}
+
+func PortScheduleHandler(w http.ResponseWriter, r *http.Request) {
+	var port mortgage.Port
+	if err := web.Decode(r, &port); err != nil {
+		log.Println("unable to decode payload: %w", err)
+		internalServerErrorResponse(w)
+		return
+	}
+
+	m := port.Mortgage()
+	paymentSchedule, err := m.PaymentSchedule()
+	if err != nil {
+		calculationErrorResponse(w, err)
+		return
+	}
+
+	schedule, err := m.AmortizationSchedule()
+	if err != nil {
+		calculationErrorResponse(w, err)
+		return
+	}
+
+	resp := portScheduleResponse{
+		PaymentPerSchedule: paymentSchedule,
+		BlendedRate:        port.BlendedRate(),
+		Schedule:           schedule,
+	}
+
+	web.Respond(w, resp, http.StatusOK)
+}
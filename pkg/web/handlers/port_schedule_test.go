@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/web"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPortScheduleHandler(t *testing.T) {
+	p := mortgage.Port{
+		Schedule: mortgage.Monthly,
+		Existing: mortgage.Tranche{Principal: 300000, AnnualInterestRate: 2.5, AmortizationPeriod: 20},
+		Increase: mortgage.Tranche{Principal: 100000, AnnualInterestRate: 5, AmortizationPeriod: 25},
+	}
+
+	t.Run("returns the combined payment, blended rate and schedule", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&p)
+		request, _ := http.NewRequest(http.MethodPost, "/portSchedule", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		PortScheduleHandler(response, request)
+		port := portScheduleResponse{}
+		json.NewDecoder(response.Body).Decode(&port)
+		tests.AssertSameMoney(t, port.PaymentPerSchedule, 2174.3)
+		tests.AssertSameFloat(t, port.BlendedRate, 3.13)
+		tests.AssertSameInt(t, len(port.Schedule), 300)
+	})
+
+	t.Run("returns not found if the path is not supported", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&p)
+		request, _ := http.NewRequest(http.MethodPost, "/test", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		API(web.CORS{}).ServeHTTP(response, request)
+		if response.Code != http.StatusNotFound {
+			t.Errorf("got %v, want %v", response.Code, http.StatusNotFound)
+		}
+	})
+
+	t.Run("returns internal server error if request body is not present", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPost, "/portSchedule", http.NoBody)
+		response := httptest.NewRecorder()
+		PortScheduleHandler(response, request)
+		if response.Code != http.StatusInternalServerError {
+			t.Errorf("got %v, want %v", response.Code, http.StatusInternalServerError)
+		}
+	})
+
+	t.Run("returns a tranche amortization out of range error response", func(t *testing.T) {
+		invalid := p
+		invalid.Increase.AmortizationPeriod = 40
+		jsonBody, _ := json.Marshal(&invalid)
+		request, _ := http.NewRequest(http.MethodPost, "/portSchedule", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		PortScheduleHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		want := mortgage.ErrTrancheAmortizationOutOfRange.Error()
+		if err.Error != want {
+			t.Errorf("got %s, want %s", err.Error, want)
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/propertytax"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPropertyTaxHandler(t *testing.T) {
+	t.Run("returns the property tax less the home owner grant", func(t *testing.T) {
+		p := propertytax.Property{AssessedValue: 1000000, Municipality: "Vancouver", PrincipalResidence: true}
+		jsonBody, _ := json.Marshal(&p)
+		request, _ := http.NewRequest(http.MethodPost, "/propertyTax", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		PropertyTaxHandler(response, request)
+		resp := propertytax.Estimate{}
+		json.NewDecoder(response.Body).Decode(&resp)
+		tests.AssertSameMoney(t, resp.NetTax, 2233.60)
+	})
+
+	t.Run("returns a bad request for an unknown municipality", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&propertytax.Property{AssessedValue: 1000000, Municipality: "Atlantis"})
+		request, _ := http.NewRequest(http.MethodPost, "/propertyTax", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		PropertyTaxHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		want := propertytax.ErrUnknownMunicipality.Error()
+		if err.Error != want {
+			t.Errorf("got %s, want %s", err.Error, want)
+		}
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("got %v, want %v", response.Code, http.StatusBadRequest)
+		}
+	})
+}
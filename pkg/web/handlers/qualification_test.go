@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestQualificationHandler(t *testing.T) {
+	q := mortgage.Qualification{
+		Calculator: mortgage.Calculator{
+			PropertyPrice:      500000,
+			DownPayment:        100000,
+			AnnualInterestRate: 5,
+			AmortizationPeriod: 25,
+			Schedule:           mortgage.Monthly,
+		},
+		AnnualIncome:        120000,
+		PropertyTax:         3000,
+		HeatingCost:         100,
+		OtherDebtPayments:   500,
+		MonthlyRentalIncome: 1500,
+	}
+
+	t.Run("returns the debt service with and without the rental income", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&q)
+		request, _ := http.NewRequest(http.MethodPost, "/qualification", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		QualificationHandler(response, request)
+		resp := mortgage.QualificationResult{}
+		json.NewDecoder(response.Body).Decode(&resp)
+		tests.AssertSameFloat(t, resp.DebtService.GrossDebtService, 29.55)
+		tests.AssertSameFloat(t, resp.WithoutRentalIncome.GrossDebtService, 31.77)
+	})
+
+	t.Run("returns a bad request when the rental treatment is invalid", func(t *testing.T) {
+		invalid := q
+		invalid.RentalTreatment = "ignore"
+		jsonBody, _ := json.Marshal(&invalid)
+		request, _ := http.NewRequest(http.MethodPost, "/qualification", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		QualificationHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		want := mortgage.ErrInvalidRentalTreatment.Error()
+		if err.Error != want {
+			t.Errorf("got %s, want %s", err.Error, want)
+		}
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("got %v, want %v", response.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("returns a bad request when the monthly income rounds to zero", func(t *testing.T) {
+		invalid := q
+		invalid.AnnualIncome = 0.004
+		jsonBody, _ := json.Marshal(&invalid)
+		request, _ := http.NewRequest(http.MethodPost, "/qualification", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		QualificationHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		if len(err.Fields) == 0 || err.Fields[0].Field != "annualIncome" {
+			t.Errorf("got %v, want an annualIncome field error", err.Fields)
+		}
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("got %v, want %v", response.Code, http.StatusBadRequest)
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRateSimulationHandler(t *testing.T) {
+	s := mortgage.Simulation{
+		Calculator: mortgage.Calculator{
+			PropertyPrice:      500000,
+			DownPayment:        100000,
+			AnnualInterestRate: 5,
+			AmortizationPeriod: 25,
+			Schedule:           mortgage.Monthly,
+		},
+		Model: mortgage.RateModel{LongTermRate: 4, Reversion: 0.3, Volatility: 1},
+		Paths: 100,
+		Seed:  1,
+	}
+
+	t.Run("returns the percentiles of every renewal", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&s)
+		request, _ := http.NewRequest(http.MethodPost, "/rateSimulation", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		RateSimulationHandler(response, request)
+		resp := mortgage.SimulationResult{}
+		json.NewDecoder(response.Body).Decode(&resp)
+		tests.AssertSameInt(t, len(resp.Renewals), 5)
+		tests.AssertSameMoney(t, resp.Renewals[0].Payment.P50, 2338.36)
+	})
+
+	t.Run("returns a validation error without paths", func(t *testing.T) {
+		invalid := s
+		invalid.Paths = 0
+		jsonBody, _ := json.Marshal(&invalid)
+		request, _ := http.NewRequest(http.MethodPost, "/rateSimulation", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		RateSimulationHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		if err.Fields[0].Field != "paths" {
+			t.Errorf("got %v, want paths", err.Fields)
+		}
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("got %v, want %v", response.Code, http.StatusBadRequest)
+		}
+	})
+}
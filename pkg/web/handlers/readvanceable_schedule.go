@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/web"
+	"log"
+	"net/http"
+)
+
+type readvanceableScheduleResponse struct {
+	Schedule []mortgage.ReadvanceablePeriod `json:"schedule"`
+}
+
+func ReadvanceableScheduleHandler(w http.ResponseWriter, r *http.Request) {
+	var readvanceable mortgage.Readvanceable
+	if err := web.Decode(r, &readvanceable); err != nil {
+		log.Println("unable to decode payload: %w", err)
+		internalServerErrorResponse(w)
+		return
+	}
+
+	schedule, err := readvanceable.Simulate()
+	if err != nil {
+		calculationErrorResponse(w, err)
+		return
+	}
+	resp := readvanceableScheduleResponse{Schedule: schedule}
+
+	web.Respond(w, resp, http.StatusOK)
+}
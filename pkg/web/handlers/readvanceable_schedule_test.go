@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/web"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReadvanceableScheduleHandler(t *testing.T) {
+	readvanceable := mortgage.Readvanceable{
+		PropertyValue:     500000,
+		Schedule:          mortgage.Monthly,
+		Amortizing:        mortgage.Tranche{Principal: 300000, AnnualInterestRate: 5, AmortizationPeriod: 25},
+		HelocInterestRate: 6,
+		HelocBalance:      50000,
+	}
+
+	t.Run("returns the readvanceable schedule", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&readvanceable)
+		request, _ := http.NewRequest(http.MethodPost, "/readvanceableSchedule", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		ReadvanceableScheduleHandler(response, request)
+		resp := readvanceableScheduleResponse{}
+		json.NewDecoder(response.Body).Decode(&resp)
+		tests.AssertSameInt(t, len(resp.Schedule), 300)
+		tests.AssertSameMoney(t, resp.Schedule[0].HelocLimit, 100503.77)
+		tests.AssertSameMoney(t, resp.Schedule[0].HelocInterestPayment, 250)
+	})
+
+	t.Run("returns a loan to value exceeded error response", func(t *testing.T) {
+		invalid := readvanceable
+		invalid.HelocBalance = 150000
+		jsonBody, _ := json.Marshal(&invalid)
+		request, _ := http.NewRequest(http.MethodPost, "/readvanceableSchedule", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		ReadvanceableScheduleHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		want := mortgage.ErrLoanToValueExceeded.Error()
+		if err.Error != want {
+			t.Errorf("got %s, want %s", err.Error, want)
+		}
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("got %v, want %v", response.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("returns not found if the path is not supported", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPost, "/test", http.NoBody)
+		response := httptest.NewRecorder()
+		API(web.CORS{}).ServeHTTP(response, request)
+		if response.Code != http.StatusNotFound {
+			t.Errorf("got %v, want %v", response.Code, http.StatusNotFound)
+		}
+	})
+}
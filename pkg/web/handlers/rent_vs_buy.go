@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/web"
+	"log"
+	"net/http"
+)
+
+func RentVsBuyHandler(w http.ResponseWriter, r *http.Request) {
+	var rentVsBuy mortgage.RentVsBuy
+	if err := web.Decode(r, &rentVsBuy); err != nil {
+		log.Println("unable to decode payload: %w", err)
+		internalServerErrorResponse(w)
+		return
+	}
+
+	analysis, err := rentVsBuy.Analyze()
+	if err != nil {
+		calculationErrorResponse(w, err)
+		return
+	}
+
+	web.Respond(w, analysis, http.StatusOK)
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRentVsBuyHandler(t *testing.T) {
+	r := mortgage.RentVsBuy{
+		Calculator: mortgage.Calculator{
+			PropertyPrice:      500000,
+			DownPayment:        100000,
+			AnnualInterestRate: 5,
+			AmortizationPeriod: 25,
+			Schedule:           mortgage.Monthly,
+		},
+		Years:           10,
+		PropertyTaxRate: 0.3,
+		Rent:            2000,
+	}
+
+	t.Run("returns the net worth of both paths every year", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&r)
+		request, _ := http.NewRequest(http.MethodPost, "/rentVsBuy", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		RentVsBuyHandler(response, request)
+		resp := mortgage.RentVsBuyAnalysis{}
+		json.NewDecoder(response.Body).Decode(&resp)
+		tests.AssertSameInt(t, len(resp.Years), 10)
+		tests.AssertSameMoney(t, resp.Years[0].OwnershipCost, 2338.36*12+1500)
+	})
+
+	t.Run("returns a validation error without rent", func(t *testing.T) {
+		invalid := r
+		invalid.Rent = 0
+		jsonBody, _ := json.Marshal(&invalid)
+		request, _ := http.NewRequest(http.MethodPost, "/rentVsBuy", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		RentVsBuyHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		if err.Fields[0].Field != "rent" {
+			t.Errorf("got %v, want rent", err.Fields)
+		}
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("got %v, want %v", response.Code, http.StatusBadRequest)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSavingsPlanHandler(t *testing.T) {
+	s := mortgage.SavingsPlan{
+		TargetPrice:         500000,
+		Savings:             10000,
+		MonthlyContribution: 1000,
+		OtherClosingCosts:   2000,
+		StartDate:           "2024-01-15",
+	}
+
+	t.Run("returns when the savings reach the goal", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&s)
+		request, _ := http.NewRequest(http.MethodPost, "/savingsPlan", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		SavingsPlanHandler(response, request)
+		resp := mortgage.SavingsGoal{}
+		json.NewDecoder(response.Body).Decode(&resp)
+		tests.AssertSameInt(t, resp.Months, 25)
+		tests.AssertSameMoney(t, resp.Needed, 35000)
+	})
+
+	t.Run("returns a bad request when the goal is unreachable", func(t *testing.T) {
+		invalid := s
+		invalid.MonthlyContribution = 0
+		jsonBody, _ := json.Marshal(&invalid)
+		request, _ := http.NewRequest(http.MethodPost, "/savingsPlan", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		SavingsPlanHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		want := mortgage.ErrSavingsGoalUnreachable.Error()
+		if err.Error != want {
+			t.Errorf("got %s, want %s", err.Error, want)
+		}
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("got %v, want %v", response.Code, http.StatusBadRequest)
+		}
+	})
+}
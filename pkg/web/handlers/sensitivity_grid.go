@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/web"
+	"log"
+	"net/http"
+)
+
+func SensitivityGridHandler(w http.ResponseWriter, r *http.Request) {
+	var sensitivity mortgage.Sensitivity
+	if err := web.Decode(r, &sensitivity); err != nil {
+		log.Println("unable to decode payload: %w", err)
+		internalServerErrorResponse(w)
+		return
+	}
+
+	grid, err := sensitivity.Grid()
+	if err != nil {
+		calculationErrorResponse(w, err)
+		return
+	}
+
+	web.Respond(w, grid, http.StatusOK)
+}
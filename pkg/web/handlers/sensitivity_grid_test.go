@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSensitivityGridHandler(t *testing.T) {
+	base := mortgage.Calculator{
+		PropertyPrice:      500000,
+		DownPayment:        100000,
+		AnnualInterestRate: 5,
+		AmortizationPeriod: 25,
+		Schedule:           mortgage.Monthly,
+	}
+
+	t.Run("returns the grid of payments", func(t *testing.T) {
+		s := mortgage.Sensitivity{Base: base, Axes: []mortgage.Axis{
+			{Field: "annualInterestRate", From: 4, To: 5, Step: 0.25},
+			{Field: "amortizationPeriod", From: 25, To: 30, Step: 5},
+		}}
+		jsonBody, _ := json.Marshal(&s)
+		request, _ := http.NewRequest(http.MethodPost, "/sensitivityGrid", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		SensitivityGridHandler(response, request)
+		resp := mortgage.SensitivityGrid{}
+		json.NewDecoder(response.Body).Decode(&resp)
+		tests.AssertSameInt(t, len(resp.Rows.Values), 5)
+		tests.AssertSameInt(t, len(resp.Columns.Values), 2)
+		tests.AssertSameMoney(t, resp.Cells[4][0].PaymentPerSchedule, 2338.36)
+	})
+
+	t.Run("returns a bad request for grids larger than the limit", func(t *testing.T) {
+		s := mortgage.Sensitivity{Base: base, Axes: []mortgage.Axis{
+			{Field: "annualInterestRate", From: 0, To: 100, Step: 0.01},
+		}}
+		jsonBody, _ := json.Marshal(&s)
+		request, _ := http.NewRequest(http.MethodPost, "/sensitivityGrid", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		SensitivityGridHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		want := mortgage.ErrGridTooLarge.Error()
+		if err.Error != want {
+			t.Errorf("got %s, want %s", err.Error, want)
+		}
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("got %v, want %v", response.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("returns a bad request with the field errors of the cell that failed", func(t *testing.T) {
+		s := mortgage.Sensitivity{Base: base, Axes: []mortgage.Axis{
+			{Field: "downPayment", From: 0, To: 100000, Step: 50000},
+		}}
+		jsonBody, _ := json.Marshal(&s)
+		request, _ := http.NewRequest(http.MethodPost, "/sensitivityGrid", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		SensitivityGridHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		if len(err.Fields) == 0 || err.Fields[0].Field != "downPayment" {
+			t.Errorf("got %v, want a downPayment field error", err.Fields)
+		}
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("got %v, want %v", response.Code, http.StatusBadRequest)
+		}
+	})
+}
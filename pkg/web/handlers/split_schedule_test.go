@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/mortgage"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/web"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSplitScheduleHandler(t *testing.T) {
+	m := mortgage.Mortgage{
+		Schedule: mortgage.Monthly,
+		Tranches: []mortgage.Tranche{
+			{Name: "fixed", Principal: 300000, AnnualInterestRate: 4.29, AmortizationPeriod: 25, Term: 5},
+			{Name: "variable", Principal: 100000, AnnualInterestRate: 5.2, AmortizationPeriod: 10, Term: 3, RateType: "variable"},
+		},
+	}
+
+	t.Run("returns the per tranche and aggregated outputs", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&m)
+		request, _ := http.NewRequest(http.MethodPost, "/splitSchedule", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		SplitScheduleHandler(response, request)
+		breakdown := mortgage.Breakdown{}
+		json.NewDecoder(response.Body).Decode(&breakdown)
+		tests.AssertSameInt(t, len(breakdown.Tranches), 2)
+		tests.AssertSameMoney(t, breakdown.Tranches[0].PaymentPerSchedule, 1631.94)
+		tests.AssertSameMoney(t, breakdown.Tranches[1].PaymentPerSchedule, 1070.46)
+		tests.AssertSameMoney(t, breakdown.PaymentPerSchedule, 2702.4)
+	})
+
+	t.Run("returns method not allowed if the http method is not supported", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/splitSchedule", http.NoBody)
+		response := httptest.NewRecorder()
+		API(web.CORS{}).ServeHTTP(response, request)
+		if response.Code != http.StatusMethodNotAllowed {
+			t.Errorf("got %v, want %v", response.Code, http.StatusMethodNotAllowed)
+		}
+	})
+
+	t.Run("returns field errors if the body does not pass validations", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&mortgage.Mortgage{Schedule: mortgage.Monthly})
+		request, _ := http.NewRequest(http.MethodPost, "/splitSchedule", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		SplitScheduleHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		if len(err.Fields) == 0 || err.Fields[0].Field != "tranches" {
+			t.Errorf("got %v, want a tranches field error", err)
+		}
+	})
+
+	t.Run("returns a term longer than amortization error response", func(t *testing.T) {
+		invalid := mortgage.Mortgage{
+			Schedule: mortgage.Monthly,
+			Tranches: []mortgage.Tranche{{Principal: 100000, AnnualInterestRate: 5, AmortizationPeriod: 5, Term: 10}},
+		}
+		jsonBody, _ := json.Marshal(&invalid)
+		request, _ := http.NewRequest(http.MethodPost, "/splitSchedule", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		SplitScheduleHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		want := mortgage.ErrTermLongerThanAmortization.Error()
+		if err.Error != want {
+			t.Errorf("got %s, want %s", err.Error, want)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/province"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTransferTaxesHandler(t *testing.T) {
+	t.Run("returns the bc property transfer tax by default", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&province.Purchase{Price: 500000})
+		request, _ := http.NewRequest(http.MethodPost, "/transferTaxes", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		TransferTaxesHandler(response, request)
+		resp := province.TransferTaxes{}
+		json.NewDecoder(response.Body).Decode(&resp)
+		tests.AssertSameMoney(t, resp.Total, 8000)
+	})
+
+	t.Run("returns the municipal land transfer tax of toronto", func(t *testing.T) {
+		p := province.Purchase{Province: "ON", Price: 500000, Municipality: "Toronto"}
+		jsonBody, _ := json.Marshal(&p)
+		request, _ := http.NewRequest(http.MethodPost, "/transferTaxes", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		TransferTaxesHandler(response, request)
+		resp := province.TransferTaxes{}
+		json.NewDecoder(response.Body).Decode(&resp)
+		tests.AssertSameMoney(t, resp.MunicipalLandTransferTax, 6475)
+	})
+
+	t.Run("returns a bad request for an unsupported province", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&province.Purchase{Province: "AB", Price: 500000})
+		request, _ := http.NewRequest(http.MethodPost, "/transferTaxes", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		TransferTaxesHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		want := province.ErrUnsupportedProvince.Error()
+		if err.Error != want {
+			t.Errorf("got %s, want %s", err.Error, want)
+		}
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("got %v, want %v", response.Code, http.StatusBadRequest)
+		}
+	})
+}
@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/propertytax"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/web"
+	"log"
+	"net/http"
+)
+
+func VacancyTaxesHandler(w http.ResponseWriter, r *http.Request) {
+	var vacancy propertytax.Vacancy
+	if err := web.Decode(r, &vacancy); err != nil {
+		log.Println("unable to decode payload: %w", err)
+		internalServerErrorResponse(w)
+		return
+	}
+
+	taxes, err := vacancy.Taxes()
+	if err != nil {
+		calculationErrorResponse(w, err)
+		return
+	}
+
+	web.Respond(w, taxes, http.StatusOK)
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/propertytax"
+	"github.com/jhidalgoesp/bc-mortgage-calculator/pkg/tests"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVacancyTaxesHandler(t *testing.T) {
+	t.Run("returns the vacancy taxes of a vacant home", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(&propertytax.Vacancy{AssessedValue: 1000000, Municipality: "Vancouver"})
+		request, _ := http.NewRequest(http.MethodPost, "/vacancyTaxes", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		VacancyTaxesHandler(response, request)
+		resp := propertytax.VacancyTaxes{}
+		json.NewDecoder(response.Body).Decode(&resp)
+		tests.AssertSameMoney(t, resp.Total, 40000)
+	})
+
+	t.Run("returns a bad request for an invalid residency", func(t *testing.T) {
+		v := propertytax.Vacancy{AssessedValue: 1000000, Municipality: "Vancouver"}
+		v.Residency = "tourist"
+		jsonBody, _ := json.Marshal(&v)
+		request, _ := http.NewRequest(http.MethodPost, "/vacancyTaxes", bytes.NewBuffer(jsonBody))
+		response := httptest.NewRecorder()
+		VacancyTaxesHandler(response, request)
+		err := errorResponse{}
+		json.NewDecoder(response.Body).Decode(&err)
+		want := propertytax.ErrInvalidResidency.Error()
+		if err.Error != want {
+			t.Errorf("got %s, want %s", err.Error, want)
+		}
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("got %v, want %v", response.Code, http.StatusBadRequest)
+		}
+	})
+}
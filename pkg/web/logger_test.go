@@ -0,0 +1,29 @@
+package web
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLogRequests(t *testing.T) {
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+
+	teapot := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusTeapot) })
+	request, _ := http.NewRequest(http.MethodPost, "/v1/paymentSchedule", http.NoBody)
+	response := httptest.NewRecorder()
+	LogRequests(teapot).ServeHTTP(response, request)
+
+	if response.Code != http.StatusTeapot {
+		t.Errorf("got %v, want %v", response.Code, http.StatusTeapot)
+	}
+	if want := "POST /v1/paymentSchedule 418 "; !strings.Contains(output.String(), want) {
+		t.Errorf("got %q, want it to contain %q", output.String(), want)
+	}
+}
@@ -0,0 +1,100 @@
+package web
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Router dispatches requests to the handler registered for their path and method. Requests to a registered path with
+// a method it does not handle are answered with a method not allowed status and the Allow header, OPTIONS requests are
+// answered by the Options handler when the path has no handler of its own for them.
+type Router struct {
+	// NotFound handles the requests to paths without handlers, http.NotFound when nil.
+	NotFound http.HandlerFunc
+
+	// MethodNotAllowed handles the requests with a method the path does not handle, after the Allow header is set.
+	MethodNotAllowed http.HandlerFunc
+
+	// Options handles the OPTIONS requests of the paths that do not handle them, after the Allow header is set.
+	Options http.HandlerFunc
+
+	routes map[string]map[string]http.HandlerFunc
+}
+
+// NewRouter returns a router without routes.
+func NewRouter() *Router {
+	return &Router{routes: make(map[string]map[string]http.HandlerFunc)}
+}
+
+// Handle registers the handler for the requests with the method to the path, replacing any previous one.
+func (rt *Router) Handle(method, path string, handler http.HandlerFunc) {
+	methods, ok := rt.routes[path]
+	if !ok {
+		methods = make(map[string]http.HandlerFunc)
+		rt.routes[path] = methods
+	}
+	methods[strings.ToUpper(method)] = handler
+}
+
+// Group returns a group registering its routes under the path prefix, such as an API version.
+func (rt *Router) Group(prefix string) *Group {
+	return &Group{router: rt, prefix: strings.TrimSuffix(prefix, "/")}
+}
+
+// ServeHTTP dispatches the request to the handler registered for its path and method.
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	methods, ok := rt.routes[r.URL.Path]
+	if !ok {
+		if rt.NotFound == nil {
+			http.NotFound(w, r)
+			return
+		}
+		rt.NotFound(w, r)
+		return
+	}
+
+	if handler, ok := methods[r.Method]; ok {
+		handler(w, r)
+		return
+	}
+
+	w.Header().Set("Allow", allow(methods))
+	if r.Method == http.MethodOptions {
+		if rt.Options == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		rt.Options(w, r)
+		return
+	}
+
+	if rt.MethodNotAllowed == nil {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	rt.MethodNotAllowed(w, r)
+}
+
+// allow returns the value of the Allow header of a path handling the methods, which always allows OPTIONS.
+func allow(methods map[string]http.HandlerFunc) string {
+	allowed := []string{http.MethodOptions}
+	for method := range methods {
+		if method != http.MethodOptions {
+			allowed = append(allowed, method)
+		}
+	}
+	sort.Strings(allowed)
+	return strings.Join(allowed, ", ")
+}
+
+// Group registers routes under a path prefix of a router.
+type Group struct {
+	router *Router
+	prefix string
+}
+
+// Handle registers the handler for the requests with the method to the path under the prefix of the group.
+func (g *Group) Handle(method, path string, handler http.HandlerFunc) {
+	g.router.Handle(method, g.prefix+path, handler)
+}
@@ -0,0 +1,83 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouter(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+	teapot := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusTeapot) }
+
+	router := NewRouter()
+	router.Handle(http.MethodPost, "/paymentSchedule", ok)
+	router.Group("/v1/").Handle(http.MethodPost, "/paymentSchedule", ok)
+	router.Group("/v1").Handle(http.MethodGet, "/paymentSchedule", teapot)
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		request, _ := http.NewRequest(method, path, http.NoBody)
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+		return response
+	}
+
+	t.Run("dispatches the request to the handler of its path and method", func(t *testing.T) {
+		for method, want := range map[string]int{http.MethodPost: http.StatusOK, http.MethodGet: http.StatusTeapot} {
+			if got := serve(method, "/v1/paymentSchedule").Code; got != want {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		}
+		if got := serve(http.MethodPost, "/paymentSchedule").Code; got != http.StatusOK {
+			t.Errorf("got %v, want %v", got, http.StatusOK)
+		}
+	})
+
+	t.Run("responds not found to unknown paths", func(t *testing.T) {
+		if got := serve(http.MethodPost, "/v2/paymentSchedule").Code; got != http.StatusNotFound {
+			t.Errorf("got %v, want %v", got, http.StatusNotFound)
+		}
+	})
+
+	t.Run("responds method not allowed with the allowed methods", func(t *testing.T) {
+		response := serve(http.MethodDelete, "/v1/paymentSchedule")
+		if response.Code != http.StatusMethodNotAllowed {
+			t.Errorf("got %v, want %v", response.Code, http.StatusMethodNotAllowed)
+		}
+		if got, want := response.Header().Get("Allow"), "GET, OPTIONS, POST"; got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("responds to options requests with the allowed methods", func(t *testing.T) {
+		response := serve(http.MethodOptions, "/paymentSchedule")
+		if response.Code != http.StatusNoContent {
+			t.Errorf("got %v, want %v", response.Code, http.StatusNoContent)
+		}
+		if got, want := response.Header().Get("Allow"), "OPTIONS, POST"; got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("uses the handlers provided for unknown paths and methods", func(t *testing.T) {
+		custom := NewRouter()
+		custom.Handle(http.MethodPost, "/paymentSchedule", ok)
+		custom.NotFound = teapot
+		custom.MethodNotAllowed = teapot
+		custom.Options = teapot
+		for _, method := range []string{http.MethodGet, http.MethodOptions} {
+			request, _ := http.NewRequest(method, "/paymentSchedule", http.NoBody)
+			response := httptest.NewRecorder()
+			custom.ServeHTTP(response, request)
+			if response.Code != http.StatusTeapot {
+				t.Errorf("got %v, want %v", response.Code, http.StatusTeapot)
+			}
+		}
+		request, _ := http.NewRequest(http.MethodPost, "/test", http.NoBody)
+		response := httptest.NewRecorder()
+		custom.ServeHTTP(response, request)
+		if response.Code != http.StatusTeapot {
+			t.Errorf("got %v, want %v", response.Code, http.StatusTeapot)
+		}
+	})
+}